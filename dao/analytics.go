@@ -0,0 +1,124 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"context"
+	"crypto/md5"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RecordProfileView stores the view unless the same viewer already saw the profile that day,
+// and reports whether it was new.
+func RecordProfileView(ctx context.Context, view *domain.ProfileView) (bool, error) {
+	viewCol := db.Database.Collection("profile_views")
+
+	res, err := viewCol.UpdateOne(ctx, bson.M{"_id": view.ID}, bson.M{"$setOnInsert": view}, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+
+	return res.UpsertedCount == 1, nil
+}
+
+// RecordSearchAppearances increments the daily counter of every engineer shown in a listing.
+func RecordSearchAppearances(ctx context.Context, engineerIDs []uuid.UUID, day string) error {
+	if len(engineerIDs) == 0 {
+		return nil
+	}
+
+	appearanceCol := db.Database.Collection("search_appearances")
+
+	models := make([]mongo.WriteModel, 0, len(engineerIDs))
+	for _, engineerID := range engineerIDs {
+		counterHash := md5.Sum([]byte(engineerID.String() + day))
+		counterID, err := uuid.FromBytes(counterHash[:])
+		if err != nil {
+			return err
+		}
+
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": counterID}).
+			SetUpdate(bson.M{
+				"$setOnInsert": bson.M{"engineer_id": engineerID, "day": day},
+				"$inc": bson.M{"count": 1},
+			}).
+			SetUpsert(true))
+	}
+
+	_, err := appearanceCol.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func ReadEngineerStats(ctx context.Context, engineerID uuid.UUID, fromDay, toDay string) (*domain.EngineerStats, error) {
+	viewCol := db.Database.Collection("profile_views")
+	appearanceCol := db.Database.Collection("search_appearances")
+
+	match := bson.M{"engineer_id": engineerID, "day": bson.M{"$gte": fromDay, "$lte": toDay}}
+	stats := &domain.EngineerStats{From: fromDay, To: toDay, ViewerCompanies: []domain.CompanyViews{}}
+
+	dailyPipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$day", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+	err := aggregate(ctx, viewCol, dailyPipeline, &stats.DailyViews)
+	if err != nil {
+		return nil, err
+	}
+
+	appearancePipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$day", "count": bson.M{"$sum": "$count"}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+	err = aggregate(ctx, appearanceCol, appearancePipeline, &stats.DailySearchAppearances)
+	if err != nil {
+		return nil, err
+	}
+
+	companyMatch := bson.M{"engineer_id": engineerID, "day": bson.M{"$gte": fromDay, "$lte": toDay}, "is_member": true, "company": bson.M{"$nin": bson.A{nil, ""}}}
+	companyPipeline := mongo.Pipeline{
+		{{Key: "$match", Value: companyMatch}},
+		{{Key: "$group", Value: bson.M{"_id": "$company", "views": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "views", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	err = aggregate(ctx, viewCol, companyPipeline, &stats.ViewerCompanies)
+	if err != nil {
+		return nil, err
+	}
+
+	viewers, err := viewCol.Distinct(ctx, "viewer_key", match)
+	if err != nil {
+		return nil, err
+	}
+	stats.UniqueViewers = int64(len(viewers))
+
+	memberMatch := bson.M{"engineer_id": engineerID, "day": bson.M{"$gte": fromDay, "$lte": toDay}, "is_member": true}
+	stats.MemberViews, err = viewCol.CountDocuments(ctx, memberMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, day := range stats.DailyViews {
+		stats.TotalViews += day.Count
+	}
+	for _, day := range stats.DailySearchAppearances {
+		stats.SearchAppearances += day.Count
+	}
+
+	return stats, nil
+}
+
+func aggregate(ctx context.Context, col *mongo.Collection, pipeline mongo.Pipeline, results interface{}) error {
+	cur, err := col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+
+	return cur.All(ctx, results)
+}
@@ -0,0 +1,156 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// completenessBackfillBatch bounds the profiles scored per run for documents written before
+// scores were stored.
+const completenessBackfillBatch = 500
+
+// storeEngineerCompleteness keeps the stored score, which listings sort on, in line with the
+// engineer after an update.
+func storeEngineerCompleteness(ctx context.Context, engineer *domain.Engineer) error {
+	score := engineer.Completeness().Score
+	if score == engineer.CompletenessScore {
+		return nil
+	}
+
+	err := storeCompleteness(ctx, "engineers", engineer.ID, score)
+	if err != nil {
+		return err
+	}
+
+	engineer.CompletenessScore = score
+	return nil
+}
+
+func storeRecruiterCompleteness(ctx context.Context, recruiter *domain.Recruiter) error {
+	score := recruiter.Completeness().Score
+	if score == recruiter.CompletenessScore {
+		return nil
+	}
+
+	err := storeCompleteness(ctx, "recruiters", recruiter.ID, score)
+	if err != nil {
+		return err
+	}
+
+	recruiter.CompletenessScore = score
+	return nil
+}
+
+func storeCompleteness(ctx context.Context, collection string, profileID uuid.UUID, score int) error {
+	_, err := db.Database.Collection(collection).UpdateOne(ctx, bson.M{"_id": profileID}, bson.M{"$set": bson.M{"completeness_score": score}})
+	return err
+}
+
+// BackfillCompleteness scores a batch of the profiles that have no stored score yet, returning how
+// many it scored.
+func BackfillCompleteness(ctx context.Context) (int, error) {
+	filter := bson.M{"completeness_score": bson.M{"$exists": false}}
+	findOptions := options.Find().SetLimit(completenessBackfillBatch)
+
+	engCur, err := db.Database.Collection("engineers").Find(ctx, filter, findOptions)
+	if err != nil {
+		return 0, err
+	}
+
+	engineers := []*domain.Engineer{}
+	err = engCur.All(ctx, &engineers)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, engineer := range engineers {
+		err := storeCompleteness(ctx, "engineers", engineer.ID, engineer.Completeness().Score)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	recruiterCur, err := db.Database.Collection("recruiters").Find(ctx, filter, findOptions)
+	if err != nil {
+		return 0, err
+	}
+
+	recruiters := []*domain.Recruiter{}
+	err = recruiterCur.All(ctx, &recruiters)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, recruiter := range recruiters {
+		err := storeCompleteness(ctx, "recruiters", recruiter.ID, recruiter.Completeness().Score)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(engineers) + len(recruiters), nil
+}
+
+// completenessNudgeFilter matches the profiles scoring below the threshold that haven't been
+// updated, nor nudged, since the cutoff, and haven't had all their nudges yet.
+func completenessNudgeFilter(settings domain.CompletenessNudgeSettings, cutoff time.Time) bson.M {
+	return bson.M{
+		"completeness_score": bson.M{"$lt": settings.Threshold},
+		"completeness_nudges": bson.M{"$not": bson.M{"$gte": settings.MaxNudges}},
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"updated_at": bson.M{"$lt": cutoff}},
+				bson.M{"updated_at": bson.M{"$exists": false}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"completeness_nudged_at": bson.M{"$lt": cutoff}},
+				bson.M{"completeness_nudged_at": bson.M{"$exists": false}},
+			}},
+		},
+	}
+}
+
+func ReadEngineersToNudge(ctx context.Context, settings domain.CompletenessNudgeSettings, cutoff time.Time) ([]*domain.Engineer, error) {
+	cur, err := db.Database.Collection("engineers").Find(ctx, completenessNudgeFilter(settings, cutoff))
+	if err != nil {
+		return nil, err
+	}
+
+	engineers := []*domain.Engineer{}
+	err = cur.All(ctx, &engineers)
+	if err != nil {
+		return nil, err
+	}
+
+	return engineers, nil
+}
+
+func ReadRecruitersToNudge(ctx context.Context, settings domain.CompletenessNudgeSettings, cutoff time.Time) ([]*domain.Recruiter, error) {
+	cur, err := db.Database.Collection("recruiters").Find(ctx, completenessNudgeFilter(settings, cutoff))
+	if err != nil {
+		return nil, err
+	}
+
+	recruiters := []*domain.Recruiter{}
+	err = cur.All(ctx, &recruiters)
+	if err != nil {
+		return nil, err
+	}
+
+	return recruiters, nil
+}
+
+// MarkCompletenessNudged records a nudge sent to the engineer or recruiter, per profileType.
+func MarkCompletenessNudged(ctx context.Context, profileType string, profileID uuid.UUID, nudgedAt time.Time) error {
+	_, err := db.Database.Collection(profileCollections[profileType]).UpdateOne(ctx, bson.M{"_id": profileID}, bson.M{
+		"$set": bson.M{"completeness_nudged_at": nudgedAt},
+		"$inc": bson.M{"completeness_nudges": 1},
+	})
+	return err
+}
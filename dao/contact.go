@@ -0,0 +1,129 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func InsertNewContactRequest(ctx context.Context, contactRequest *domain.ContactRequest) error {
+	contactCol := db.Database.Collection("contact_requests")
+
+	_, err := contactCol.InsertOne(ctx, contactRequest)
+	return err
+}
+
+// FindOpenContactRequest returns the request between the recruiter and the engineer that keeps the
+// recruiter from sending another one, if any: a pending or accepted request, or one declined
+// within domain.ContactRequestCooldown.
+func FindOpenContactRequest(ctx context.Context, recruiterID, engineerID uuid.UUID, now time.Time) (*domain.ContactRequest, error) {
+	contactCol := db.Database.Collection("contact_requests")
+	var contactRequest domain.ContactRequest
+
+	filter := bson.M{
+		"recruiter_id": recruiterID,
+		"engineer_id": engineerID,
+		"$or": bson.A{
+			bson.M{"status": bson.M{"$in": bson.A{domain.ContactStatusPending, domain.ContactStatusAccepted}}},
+			bson.M{"status": domain.ContactStatusDeclined, "responded_at": bson.M{"$gt": now.Add(-domain.ContactRequestCooldown)}},
+		},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	err := contactCol.FindOne(ctx, filter, opts).Decode(&contactRequest)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &contactRequest, nil
+}
+
+func ReadContactRequestsByEngineer(ctx context.Context, engineerID uuid.UUID) ([]*domain.ContactRequest, error) {
+	return readContactRequests(ctx, bson.M{"engineer_id": engineerID})
+}
+
+func ReadContactRequestsByRecruiter(ctx context.Context, recruiterID uuid.UUID) ([]*domain.ContactRequest, error) {
+	return readContactRequests(ctx, bson.M{"recruiter_id": recruiterID})
+}
+
+func readContactRequests(ctx context.Context, filter bson.M) ([]*domain.ContactRequest, error) {
+	contactCol := db.Database.Collection("contact_requests")
+
+	cur, err := contactCol.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	contactRequests := []*domain.ContactRequest{}
+	err = cur.All(ctx, &contactRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	return contactRequests, nil
+}
+
+// RespondContactRequest answers a pending request addressed to the engineer. It returns nil when
+// there is no such pending request. The engineer's email is only stored on acceptance.
+func RespondContactRequest(ctx context.Context, engineerID uuid.UUID, requestID string, status, engineerEmail string) (*domain.ContactRequest, error) {
+	contactCol := db.Database.Collection("contact_requests")
+
+	parsedRequestID, err := uuid.Parse(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	set := bson.M{"status": status, "responded_at": time.Now()}
+	if status == domain.ContactStatusAccepted {
+		set["engineer_email"] = engineerEmail
+	}
+
+	filter := bson.M{"_id": parsedRequestID, "engineer_id": engineerID, "status": domain.ContactStatusPending}
+
+	var updatedRequest *domain.ContactRequest
+	err = contactCol.
+		FindOneAndUpdate(ctx, filter, bson.M{"$set": set}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updatedRequest)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return updatedRequest, nil
+}
+
+// ReadAcceptedContactEngineerIDs lists the engineers who accepted a contact request from the recruiter.
+func ReadAcceptedContactEngineerIDs(ctx context.Context, recruiterID uuid.UUID) ([]uuid.UUID, error) {
+	contactCol := db.Database.Collection("contact_requests")
+
+	values, err := contactCol.Distinct(ctx, "engineer_id", bson.M{"recruiter_id": recruiterID, "status": domain.ContactStatusAccepted})
+	if err != nil {
+		return nil, err
+	}
+
+	engineerIDs := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		binary, ok := value.(primitive.Binary)
+		if !ok {
+			continue
+		}
+
+		engineerID, err := uuid.FromBytes(binary.Data)
+		if err != nil {
+			return nil, err
+		}
+		engineerIDs = append(engineerIDs, engineerID)
+	}
+
+	return engineerIDs, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"angular-talents-backend/db"
 	"angular-talents-backend/domain"
+	"time"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
@@ -16,6 +17,7 @@ import (
 func InsertNewEngineer(ctx context.Context, engineer *domain.Engineer) (string, error) {
 	engCol := db.Database.Collection("engineers")
 
+	engineer.CompletenessScore = engineer.Completeness().Score
 	insertResult, err := engCol.InsertOne(ctx, *engineer)
 	if err != nil {
 		return "",  err
@@ -76,10 +78,21 @@ func ReadEngineers(ctx context.Context, listParams *domain.ListEngineersParams)
 
 	var engineers []*domain.Engineer
 
+	filter, err := engineerListQuery(ctx, listParams)
+	if err != nil {
+		return nil, err
+	}
+
 	paginationOptions := options.Find()
 	paginationOptions.SetSkip((listParams.Pagination.Page - 1) * listParams.Pagination.Limit)
 	paginationOptions.SetLimit(listParams.Pagination.Limit)
-	cur, err := engCol.Find(ctx, listParams.Filter, paginationOptions)
+	switch listParams.Sort {
+	case domain.EngineerSortCompleteness:
+		paginationOptions.SetSort(bson.D{{Key: "completeness_score", Value: -1}, {Key: "updated_at", Value: -1}})
+	case domain.EngineerSortChanged:
+		paginationOptions.SetSort(bson.D{{Key: "updated_at", Value: 1}, {Key: "_id", Value: 1}})
+	}
+	cur, err := engCol.Find(ctx, filter, paginationOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -107,35 +120,136 @@ func ReadEngineers(ctx context.Context, listParams *domain.ListEngineersParams)
 	return engineers, nil
 }
 
-func UpdateEngineer(ctx context.Context, engineerID string, data *domain.UpdateEngineerPayload) (*domain.Engineer, error)  {
+// engineerListQuery turns list params into a mongo filter. Clauses are combined with $and so that
+// several of them can use $or, while $nearSphere stays at the top level where mongo requires it.
+func engineerListQuery(ctx context.Context, listParams *domain.ListEngineersParams) (bson.M, error) {
+	listFilter := listParams.Filter
+	filter := bson.M{}
+	clauses := bson.A{}
+
+	if listParams.Viewer != nil {
+		clauses = append(clauses, listParams.Viewer.EngineerListingFilter(listFilter.SearchStatus, listFilter.IncludeNotInterested))
+	} else if listFilter.SearchStatus != "" {
+		clauses = append(clauses, bson.M{"search_status": listFilter.SearchStatus})
+	}
+
+	if listFilter.Country != "" {
+		countryCode := domain.DefaultCityDataset().CountryCode(listFilter.Country)
+		if countryCode != "" {
+			clauses = append(clauses, bson.M{"$or": bson.A{
+				bson.M{"country": listFilter.Country},
+				bson.M{"country_code": countryCode},
+			}})
+		} else {
+			clauses = append(clauses, bson.M{"country": listFilter.Country})
+		}
+	}
+
+	if listFilter.RoleLevel != "" {
+		clauses = append(clauses, bson.M{"role_level": listFilter.RoleLevel})
+	}
+
+	if listFilter.RoleType != "" {
+		clauses = append(clauses, bson.M{"role_type": listFilter.RoleType})
+	}
+
+	if listFilter.Timezone != "" {
+		tzFilter, err := domain.ParseTimezoneFilter(listFilter.Timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		zones, err := distinctEngineerTimezones(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		clauses = append(clauses, bson.M{"timezone": bson.M{"$in": tzFilter.MatchingZones(zones, time.Now())}})
+	}
+
+	// engineers updated at the very instant are included, so that a reader resuming from the last
+	// engineer it got misses none of those sharing its update time, and excludes those it already has
+	if listParams.ChangedSince != nil {
+		clauses = append(clauses, bson.M{"updated_at": bson.M{"$gte": *listParams.ChangedSince}})
+	}
+
+	if len(listParams.ExcludeIDs) > 0 {
+		clauses = append(clauses, bson.M{"_id": bson.M{"$nin": listParams.ExcludeIDs}})
+	}
+
+	// engineers who concealed their location from the viewer can't be located by shrinking the radius
+	if listFilter.Near != nil {
+		if listParams.Viewer != nil {
+			clauses = append(clauses, domain.EngineerFieldPolicy.AllowsFilter("Location", listParams.Viewer))
+		}
+		filter["location"] = bson.M{
+			"$nearSphere": bson.M{
+				"$geometry":    listFilter.Near,
+				"$maxDistance": listFilter.RadiusKm * 1000,
+			},
+		}
+	}
+
+	if len(clauses) > 0 {
+		filter["$and"] = clauses
+	}
+
+	return filter, nil
+}
+
+func distinctEngineerTimezones(ctx context.Context) ([]string, error) {
 	engCol := db.Database.Collection("engineers")
 
-	parsedEngineerID, err := uuid.Parse(engineerID)
+	values, err := engCol.Distinct(ctx, "timezone", bson.D{})
 	if err != nil {
 		return nil, err
 	}
 
-	var udpatedEng *domain.Engineer
-	 err = engCol.
-	FindOneAndUpdate(ctx, bson.M{"_id": parsedEngineerID}, bson.M{"$set": data}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&udpatedEng)
+	zones := make([]string, 0, len(values))
+	for _, value := range values {
+		if zone, ok := value.(string); ok && zone != "" {
+			zones = append(zones, zone)
+		}
+	}
+
+	return zones, nil
+}
+
+func UpdateEngineer(ctx context.Context, engineerID string, data *domain.UpdateEngineerPayload) (*domain.Engineer, error)  {
+	parsedEngineerID, err := uuid.Parse(engineerID)
 	if err != nil {
 		return nil, err
 	}
 
-	return udpatedEng, nil
+	data.UpdatedAt = time.Now()
+
+	return updateEngineerWithHistory(ctx, bson.M{"_id": parsedEngineerID}, data.UpdateDocument())
 }
 
 func UpdateEngineerByUser(ctx context.Context, userID uuid.UUID, data *domain.UpdateEngineerPayload) (*domain.Engineer, error)  {
-	engCol := db.Database.Collection("engineers")
+	data.UpdatedAt = time.Now()
+
+	return updateEngineerWithHistory(ctx, bson.M{"user_id": userID}, data.UpdateDocument())
+}
+
+// updateEngineerWithHistory fails with mongo.ErrNoDocuments when no engineer matches, as the
+// updates above always have.
+func updateEngineerWithHistory(ctx context.Context, filter, update bson.M) (*domain.Engineer, error) {
+	var updatedEngineer domain.Engineer
+	found, err := updateProfileWithHistory(ctx, domain.ProfileTypeEngineer, filter, update, nil, &updatedEngineer)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, mongo.ErrNoDocuments
+	}
 
-	var udpatedEng *domain.Engineer
-	 err := engCol.
-	FindOneAndUpdate(ctx, bson.M{"user_id": userID}, bson.M{"$set": data}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&udpatedEng)
+	err = storeEngineerCompleteness(ctx, &updatedEngineer)
 	if err != nil {
 		return nil, err
 	}
 
-	return udpatedEng, nil
+	return &updatedEngineer, nil
 }
 
 func CountEngineers(ctx context.Context) (int64, error) {
@@ -148,3 +262,195 @@ func CountEngineers(ctx context.Context) (int64, error) {
 
 	return count, nil
 }
+
+// ReadMatchCandidates returns the engineers visible to the viewer that pass the must-have filter
+// of the matcher, at most domain.MaxMatchCandidates of them, along with how many passed it. When
+// there are more, those with the best prescore are kept, the most recently updated first.
+func ReadMatchCandidates(ctx context.Context, viewer *domain.Viewer, matcher *domain.Matcher) ([]*domain.Engineer, int64, error) {
+	engCol := db.Database.Collection("engineers")
+
+	filter := bson.M{"$and": bson.A{viewer.EngineerListingFilter("", false), matcher.MustHaveFilter()}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"match_prescore": matcher.PrescoreExpression()}}},
+		{{Key: "$sort", Value: bson.D{{Key: "match_prescore", Value: -1}, {Key: "updated_at", Value: -1}}}},
+		{{Key: "$limit", Value: domain.MaxMatchCandidates}},
+		{{Key: "$project", Value: bson.M{"match_prescore": 0}}},
+	}
+
+	engineers := []*domain.Engineer{}
+	err := aggregate(ctx, engCol, pipeline, &engineers)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := int64(len(engineers))
+	if total < domain.MaxMatchCandidates {
+		return engineers, total, nil
+	}
+
+	total, err = engCol.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return engineers, total, nil
+}
+
+// AddEngineerSectionEntry appends an entry to one of the engineer's profile sections.
+func AddEngineerSectionEntry(ctx context.Context, userID uuid.UUID, section domain.ProfileSection, entry interface{}) (*domain.Engineer, error) {
+	return findAndUpdateEngineerWithHistory(ctx, bson.M{"user_id": userID}, bson.M{
+		"$push": bson.M{section.Field: sectionPush(section, bson.A{entry})},
+		"$set": bson.M{"updated_at": time.Now()},
+	})
+}
+
+// ReplaceEngineerSectionEntry returns nil when the engineer has no such entry.
+func ReplaceEngineerSectionEntry(ctx context.Context, userID uuid.UUID, section domain.ProfileSection, entryID uuid.UUID, entry interface{}) (*domain.Engineer, error) {
+	updatedEngineer, err := findAndUpdateEngineerWithHistory(ctx, bson.M{"user_id": userID, section.Field + ".id": entryID}, bson.M{
+		"$set": bson.M{section.Field + ".$": entry, "updated_at": time.Now()},
+	})
+	if err != nil || updatedEngineer == nil || section.SortKey == "" {
+		return updatedEngineer, err
+	}
+
+	// the replaced entry may have another start date, so the section is sorted again
+	return findAndUpdateEngineer(ctx, bson.M{"_id": updatedEngineer.ID}, bson.M{
+		"$push": bson.M{section.Field: sectionPush(section, bson.A{})},
+	})
+}
+
+// RemoveEngineerSectionEntry returns nil when the engineer has no such entry.
+func RemoveEngineerSectionEntry(ctx context.Context, userID uuid.UUID, section domain.ProfileSection, entryID uuid.UUID) (*domain.Engineer, error) {
+	return findAndUpdateEngineerWithHistory(ctx, bson.M{"user_id": userID, section.Field + ".id": entryID}, bson.M{
+		"$pull": bson.M{section.Field: bson.M{"id": entryID}},
+		"$set": bson.M{"updated_at": time.Now()},
+	})
+}
+
+func sectionPush(section domain.ProfileSection, entries bson.A) bson.M {
+	push := bson.M{"$each": entries}
+	if section.SortKey != "" {
+		push["$sort"] = bson.M{section.SortKey: -1}
+	}
+	return push
+}
+
+func findAndUpdateEngineer(ctx context.Context, filter, update bson.M) (*domain.Engineer, error) {
+	engCol := db.Database.Collection("engineers")
+
+	var updatedEngineer domain.Engineer
+	err := engCol.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updatedEngineer)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	err = storeEngineerCompleteness(ctx, &updatedEngineer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedEngineer, nil
+}
+
+// findAndUpdateEngineerWithHistory is findAndUpdateEngineer recording the changes in the
+// profile history.
+func findAndUpdateEngineerWithHistory(ctx context.Context, filter, update bson.M) (*domain.Engineer, error) {
+	var updatedEngineer domain.Engineer
+	found, err := updateProfileWithHistory(ctx, domain.ProfileTypeEngineer, filter, update, nil, &updatedEngineer)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	err = storeEngineerCompleteness(ctx, &updatedEngineer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedEngineer, nil
+}
+
+// ReplaceEngineerAvatar returns nil when the user has no engineer profile.
+func ReplaceEngineerAvatar(ctx context.Context, userID uuid.UUID, avatarURL string, image *domain.UploadedImage) (*domain.Engineer, error) {
+	return findAndUpdateEngineer(ctx, bson.M{"user_id": userID}, imageUpdate(bson.M{"updated_at": time.Now()}, "avatar", "avatar_image", avatarURL, image))
+}
+
+// ReadEngineersForGithubRefresh returns the engineers whose GitHub stats were last checked
+// before the given time, those never checked first. Only the fields the refresh needs are read.
+func ReadEngineersForGithubRefresh(ctx context.Context, checkedBefore time.Time, limit int64) ([]*domain.Engineer, error) {
+	engCol := db.Database.Collection("engineers")
+
+	filter := bson.M{
+		"github": bson.M{"$nin": bson.A{"", nil}},
+		"$or": bson.A{
+			bson.M{"github_checked_at": bson.M{"$exists": false}},
+			bson.M{"github_checked_at": bson.M{"$lt": checkedBefore}},
+		},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "github_checked_at", Value: 1}}).
+		SetLimit(limit).
+		SetProjection(bson.M{"_id": 1, "user_id": 1, "github": 1, "github_stats": 1, "github_checked_at": 1})
+
+	cur, err := engCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	engineers := []*domain.Engineer{}
+	err = cur.All(ctx, &engineers)
+	if err != nil {
+		return nil, err
+	}
+
+	return engineers, nil
+}
+
+// UpdateEngineerGithubStats caches the stats, removing them when nil, unless the engineer linked
+// another account than the github URL they were fetched for. It leaves updated_at alone, as the
+// engineer didn't change anything.
+func UpdateEngineerGithubStats(ctx context.Context, engineerID uuid.UUID, github string, stats *domain.GithubStats, checkedAt time.Time) error {
+	engCol := db.Database.Collection("engineers")
+
+	set := bson.M{"github_checked_at": checkedAt}
+	update := bson.M{"$set": set}
+	if stats != nil {
+		set["github_stats"] = stats
+	} else {
+		update["$unset"] = bson.M{"github_stats": ""}
+	}
+
+	_, err := engCol.UpdateOne(ctx, bson.M{"_id": engineerID, "github": github}, update)
+	return err
+}
+
+// MarkEngineerGithubChecked records an attempt to refresh the stats, keeping the cached ones,
+// unless the engineer linked another account in the meantime.
+func MarkEngineerGithubChecked(ctx context.Context, engineerID uuid.UUID, github string, checkedAt time.Time) error {
+	engCol := db.Database.Collection("engineers")
+
+	_, err := engCol.UpdateOne(ctx, bson.M{"_id": engineerID, "github": github}, bson.M{"$set": bson.M{"github_checked_at": checkedAt}})
+	return err
+}
+
+// ReadEngineersForDuplicateCheck reads the fields of every engineer that duplicates are detected on.
+func ReadEngineersForDuplicateCheck(ctx context.Context) ([]*domain.Engineer, error) {
+	engCol := db.Database.Collection("engineers")
+
+	projection := bson.M{"user_id": 1, "first_name": 1, "last_name": 1, "country": 1, "github": 1, "linkedin": 1}
+	cur, err := engCol.Find(ctx, bson.M{}, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+
+	engineers := []*domain.Engineer{}
+	err = cur.All(ctx, &engineers)
+	if err != nil {
+		return nil, err
+	}
+
+	return engineers, nil
+}
@@ -0,0 +1,259 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// historyUpdateAttempts bounds the retries when another update lands between reading a profile
+// and updating it.
+const historyUpdateAttempts = 3
+
+var profileCollections = map[string]string{
+	domain.ProfileTypeEngineer:  "engineers",
+	domain.ProfileTypeRecruiter: "recruiters",
+}
+
+// updateProfileWithHistory applies the update to the profile matching the filter, bumping its
+// version and recording the changes in the profile history. The updated profile is decoded into
+// updated, a pointer to a profile struct. It returns false when no profile matches.
+//
+// The update only applies if the version is still the one read, so the recorded changes are
+// exactly the ones the update made. When the revision can't be recorded, the profile is put back
+// as it was read, unless it changed again in the meantime, so that no version goes unrecorded.
+func updateProfileWithHistory(ctx context.Context, profileType string, filter, update bson.M, restoredFrom *int, updated interface{}) (bool, error) {
+	profileCol := db.Database.Collection(profileCollections[profileType])
+
+	for attempt := 1; attempt <= historyUpdateAttempts; attempt++ {
+		var current bson.Raw
+		err := profileCol.FindOne(ctx, filter).Decode(&current)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return false, nil
+			}
+			return false, err
+		}
+
+		before := reflect.New(reflect.TypeOf(updated).Elem()).Interface()
+		err = bson.Unmarshal(current, before)
+		if err != nil {
+			return false, err
+		}
+
+		version := 0
+		versionFilter := bson.M{"$exists": false}
+		if value, ok := current.Lookup("version").AsInt64OK(); ok {
+			version = int(value)
+			versionFilter = bson.M{"$eq": value}
+		}
+
+		versionedFilter := bson.M{"_id": current.Lookup("_id"), "version": versionFilter}
+		versionedUpdate := bson.M{"$inc": bson.M{"version": 1}}
+		for operator, fields := range update {
+			versionedUpdate[operator] = fields
+		}
+
+		err = profileCol.FindOneAndUpdate(ctx, versionedFilter, versionedUpdate, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(updated)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+
+		err = insertProfileRevision(ctx, profileType, current, version, before, updated, restoredFrom)
+		if err != nil {
+			rollbackFilter := bson.M{"_id": current.Lookup("_id"), "version": version + 1}
+			_, rollbackErr := profileCol.ReplaceOne(ctx, rollbackFilter, current)
+			if rollbackErr != nil {
+				return false, fmt.Errorf("%w, and rolling back the profile failed: %v", err, rollbackErr)
+			}
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	return false, errors.New("profile kept changing while being updated")
+}
+
+func insertProfileRevision(ctx context.Context, profileType string, current bson.Raw, version int, before, updated interface{}, restoredFrom *int) error {
+	var profileID uuid.UUID
+	err := current.Lookup("_id").Unmarshal(&profileID)
+	if err != nil {
+		return err
+	}
+
+	revision, err := domain.NewProfileRevision(ctx, profileType, profileID, version+1, before, updated, restoredFrom)
+	if err != nil || revision == nil {
+		return err
+	}
+
+	_, err = db.Database.Collection("profile_history").InsertOne(ctx, revision)
+	return err
+}
+
+// profileVersionFilter matches the profile only while it is at the version, so that an update
+// built from that version doesn't apply over later changes.
+func profileVersionFilter(profileID uuid.UUID, version int) bson.M {
+	if version == 0 {
+		return bson.M{"_id": profileID, "version": bson.M{"$exists": false}}
+	}
+	return bson.M{"_id": profileID, "version": version}
+}
+
+func ReadProfileHistory(ctx context.Context, profileType string, profileID uuid.UUID, pagination *domain.Pagination) ([]*domain.ProfileRevision, error) {
+	historyCol := db.Database.Collection("profile_history")
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "version", Value: -1}}).
+		SetSkip(pagination.Skip()).
+		SetLimit(pagination.Limit)
+
+	cur, err := historyCol.Find(ctx, bson.M{"profile_type": profileType, "profile_id": profileID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []*domain.ProfileRevision{}
+	err = cur.All(ctx, &revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func readProfileRevisionsSince(ctx context.Context, profileType string, profileID uuid.UUID, version int) ([]*domain.ProfileRevision, error) {
+	historyCol := db.Database.Collection("profile_history")
+
+	filter := bson.M{"profile_type": profileType, "profile_id": profileID, "version": bson.M{"$gt": version}}
+	cur, err := historyCol.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "version", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []*domain.ProfileRevision{}
+	err = cur.All(ctx, &revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// restoreProfileUpdate builds the update rolling the profile back to the version, which must be 0
+// or one of its recorded versions older than the current one.
+func restoreProfileUpdate(ctx context.Context, profileType string, profileID uuid.UUID, currentVersion, version int, current interface{}) (bson.M, error) {
+	if version < 0 || version >= currentVersion {
+		return nil, domain.ErrUnknownProfileVersion
+	}
+
+	revisions, err := readProfileRevisionsSince(ctx, profileType, profileID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if version > 0 {
+		count, err := db.Database.Collection("profile_history").CountDocuments(ctx, bson.M{"profile_type": profileType, "profile_id": profileID, "version": version})
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, domain.ErrUnknownProfileVersion
+		}
+	}
+
+	return domain.RestoreUpdate(profileType, current, version, revisions)
+}
+
+// RestoreEngineerVersion returns nil when the user has no engineer profile,
+// domain.ErrUnknownProfileVersion when the profile has no such earlier version, and
+// domain.ErrProfileVersionChanged when the profile changed since the restore was prepared.
+func RestoreEngineerVersion(ctx context.Context, userID uuid.UUID, version int) (*domain.Engineer, error) {
+	current, err := FindEngineerByUser(ctx, userID)
+	if err != nil || current == nil {
+		return nil, err
+	}
+
+	update, err := restoreProfileUpdate(ctx, domain.ProfileTypeEngineer, current.ID, current.Version, version, current)
+	if err != nil || update == nil {
+		return current, err
+	}
+	update["$set"].(bson.M)["updated_at"] = time.Now()
+	clearRestoredGithubStats(update)
+
+	var restored domain.Engineer
+	found, err := updateProfileWithHistory(ctx, domain.ProfileTypeEngineer, profileVersionFilter(current.ID, current.Version), update, &version, &restored)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.ErrProfileVersionChanged
+	}
+
+	err = storeEngineerCompleteness(ctx, &restored)
+	if err != nil {
+		return nil, err
+	}
+
+	return &restored, nil
+}
+
+// clearRestoredGithubStats drops the cached GitHub stats when the restore links another account.
+func clearRestoredGithubStats(update bson.M) {
+	_, set := update["$set"].(bson.M)["github"]
+	unset, _ := update["$unset"].(bson.M)
+	if _, unsetGithub := unset["github"]; !set && !unsetGithub {
+		return
+	}
+
+	if unset == nil {
+		unset = bson.M{}
+		update["$unset"] = unset
+	}
+	unset["github_stats"] = ""
+	unset["github_checked_at"] = ""
+}
+
+// RestoreRecruiterVersion returns nil when the user has no recruiter profile,
+// domain.ErrUnknownProfileVersion when the profile has no such earlier version, and
+// domain.ErrProfileVersionChanged when the profile changed since the restore was prepared.
+func RestoreRecruiterVersion(ctx context.Context, userID uuid.UUID, version int) (*domain.Recruiter, error) {
+	current, err := FindRecruiterByUser(ctx, userID)
+	if err != nil || current == nil {
+		return nil, err
+	}
+
+	update, err := restoreProfileUpdate(ctx, domain.ProfileTypeRecruiter, current.ID, current.Version, version, current)
+	if err != nil || update == nil {
+		return current, err
+	}
+	update["$set"].(bson.M)["updated_at"] = time.Now()
+
+	var restored domain.Recruiter
+	found, err := updateProfileWithHistory(ctx, domain.ProfileTypeRecruiter, profileVersionFilter(current.ID, current.Version), update, &version, &restored)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.ErrProfileVersionChanged
+	}
+
+	err = storeRecruiterCompleteness(ctx, &restored)
+	if err != nil {
+		return nil, err
+	}
+
+	return &restored, nil
+}
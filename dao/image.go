@@ -0,0 +1,35 @@
+package dao
+
+import (
+	"angular-talents-backend/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// imageUpdate adds the URL field and its uploaded image to the given $set, unsetting whichever
+// is empty so that a removed or external image leaves no stale thumbnails behind.
+func imageUpdate(set bson.M, urlField, imageField, url string, image *domain.UploadedImage) bson.M {
+	unset := bson.M{}
+
+	if url != "" {
+		set[urlField] = url
+	} else {
+		unset[urlField] = ""
+	}
+
+	if image != nil {
+		set[imageField] = image
+	} else {
+		unset[imageField] = ""
+	}
+
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	return update
+}
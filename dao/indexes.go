@@ -0,0 +1,96 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes creates the indexes the queries in this package rely on. Creating an index that
+// already exists is a no-op, so this is safe to run on every start.
+func EnsureIndexes(ctx context.Context) error {
+	indexes := map[string][]mongo.IndexModel{
+		"engineers": {
+			{Keys: bson.D{{Key: "location", Value: "2dsphere"}}},
+			{Keys: bson.D{{Key: "timezone", Value: 1}}},
+			{Keys: bson.D{{Key: "country_code", Value: 1}}},
+			{Keys: bson.D{{Key: "updated_at", Value: 1}}},
+			{Keys: bson.D{{Key: "github_checked_at", Value: 1}}},
+			{Keys: bson.D{{Key: "completeness_score", Value: -1}, {Key: "updated_at", Value: -1}}},
+			{Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+		},
+		"engineer_slugs": {
+			{Keys: bson.D{{Key: "engineer_id", Value: 1}}},
+		},
+		"users": {
+			{Keys: bson.D{{Key: "normalized_email", Value: 1}}},
+		},
+		"recruiters": {
+			{Keys: bson.D{{Key: "completeness_score", Value: 1}}},
+		},
+		"shortlists": {
+			{Keys: bson.D{{Key: "recruiter_id", Value: 1}}},
+		},
+		"contact_requests": {
+			{Keys: bson.D{{Key: "recruiter_id", Value: 1}, {Key: "status", Value: 1}}},
+			{Keys: bson.D{{Key: "engineer_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		},
+		"conversations": {
+			{Keys: bson.D{{Key: "participant_ids", Value: 1}, {Key: "last_message_at", Value: -1}}},
+		},
+		"messages": {
+			{Keys: bson.D{{Key: "conversation_id", Value: 1}, {Key: "created_at", Value: -1}}},
+			{Keys: bson.D{{Key: "recipient_id", Value: 1}, {Key: "read_at", Value: 1}}},
+		},
+		"blocks": {
+			{Keys: bson.D{{Key: "blocker_id", Value: 1}, {Key: "blocked_id", Value: 1}}},
+		},
+		"notifications": {
+			{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		},
+		"profile_views": {
+			{Keys: bson.D{{Key: "engineer_id", Value: 1}, {Key: "day", Value: 1}}},
+		},
+		"search_appearances": {
+			{Keys: bson.D{{Key: "engineer_id", Value: 1}, {Key: "day", Value: 1}}},
+		},
+		"jobs": {
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "published_at", Value: -1}}},
+			{Keys: bson.D{{Key: "recruiter_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		},
+		"interview_requests": {
+			{Keys: bson.D{{Key: "engineer_id", Value: 1}, {Key: "start", Value: 1}}},
+			{Keys: bson.D{{Key: "recruiter_id", Value: 1}, {Key: "start", Value: 1}}},
+		},
+		"interview_locks": {
+			{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		},
+		"profile_history": {
+			{Keys: bson.D{{Key: "profile_type", Value: 1}, {Key: "profile_id", Value: 1}, {Key: "version", Value: -1}}, Options: options.Index().SetUnique(true)},
+		},
+		"moderation_flags": {
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+			{Keys: bson.D{{Key: "profile_id", Value: 1}, {Key: "status", Value: 1}}},
+			{
+				Keys: bson.D{{Key: "profile_id", Value: 1}, {Key: "reporter_id", Value: 1}},
+				Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"source": "report", "status": "open"}),
+			},
+		},
+		"saved_searches": {
+			{Keys: bson.D{{Key: "recruiter_id", Value: 1}}},
+			{Keys: bson.D{{Key: "frequency", Value: 1}, {Key: "last_run_at", Value: 1}}},
+		},
+	}
+
+	for collection, models := range indexes {
+		_, err := db.Database.Collection(collection).Indexes().CreateMany(ctx, models)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func UpsertAvailability(ctx context.Context, availability *domain.Availability) error {
+	availabilityCol := db.Database.Collection("availabilities")
+
+	_, err := availabilityCol.ReplaceOne(ctx, bson.M{"_id": availability.EngineerID}, availability, options.Replace().SetUpsert(true))
+	return err
+}
+
+func FindAvailability(ctx context.Context, engineerID uuid.UUID) (*domain.Availability, error) {
+	availabilityCol := db.Database.Collection("availabilities")
+	var availability domain.Availability
+
+	err := availabilityCol.FindOne(ctx, bson.M{"_id": engineerID}).Decode(&availability)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &availability, nil
+}
+
+func InsertInterviewRequest(ctx context.Context, interview *domain.InterviewRequest) error {
+	interviewCol := db.Database.Collection("interview_requests")
+
+	_, err := interviewCol.InsertOne(ctx, interview)
+	return err
+}
+
+const (
+	// interviewLockTTL is how long a participant lock survives a crashed holder.
+	interviewLockTTL = 30 * time.Second
+	interviewLockAttempts = 20
+	interviewLockRetryDelay = 50 * time.Millisecond
+)
+
+// LockInterviewParticipants keeps other bookings of the engineer and the recruiter from checking
+// for conflicts until the returned function is called, so that checking a slot and taking it is
+// atomic. It gives up with domain.ErrSlotBusy when the lock stays held.
+func LockInterviewParticipants(ctx context.Context, engineerID, recruiterID uuid.UUID) (func(), error) {
+	token := uuid.New()
+	ids := []uuid.UUID{engineerID, recruiterID}
+	// locks are always taken in the same order, so that two bookings don't each hold one
+	if ids[1].String() < ids[0].String() {
+		ids[0], ids[1] = ids[1], ids[0]
+	}
+
+	locked := []uuid.UUID{}
+	release := func() {
+		for _, id := range locked {
+			_, err := db.Database.Collection("interview_locks").DeleteOne(context.Background(), bson.M{"_id": id, "token": token})
+			if err != nil {
+				internal.LogInfo("Failed to release interview lock", map[string]interface{}{"participant_id": id, "error": err.Error()})
+			}
+		}
+	}
+
+	for _, id := range ids {
+		err := acquireInterviewLock(ctx, id, token)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		locked = append(locked, id)
+	}
+
+	return release, nil
+}
+
+// acquireInterviewLock takes the lock of the participant unless another holder's is still valid,
+// in which case the upsert collides with it on _id.
+func acquireInterviewLock(ctx context.Context, participantID, token uuid.UUID) error {
+	lockCol := db.Database.Collection("interview_locks")
+
+	for attempt := 1; attempt <= interviewLockAttempts; attempt++ {
+		now := time.Now()
+		filter := bson.M{"_id": participantID, "expires_at": bson.M{"$lte": now}}
+		update := bson.M{"$set": bson.M{"token": token, "expires_at": now.Add(interviewLockTTL)}}
+
+		_, err := lockCol.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interviewLockRetryDelay):
+		}
+	}
+
+	return domain.ErrSlotBusy
+}
+
+// HasInterviewConflict tells whether the engineer or the recruiter already holds an interview
+// overlapping the range in one of the given statuses, ignoring the interview excludeID.
+func HasInterviewConflict(ctx context.Context, engineerID, recruiterID uuid.UUID, start, end time.Time, statuses []string, excludeID uuid.UUID) (bool, error) {
+	interviewCol := db.Database.Collection("interview_requests")
+
+	filter := bson.M{
+		"_id": bson.M{"$ne": excludeID},
+		"$or": bson.A{
+			bson.M{"engineer_id": engineerID},
+			bson.M{"recruiter_id": recruiterID},
+		},
+		"status": bson.M{"$in": statuses},
+		"start": bson.M{"$lt": end},
+		"end": bson.M{"$gt": start},
+	}
+
+	count, err := interviewCol.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return count != 0, nil
+}
+
+// ReadEngineerBusySlots returns the ranges held by the engineer's pending and confirmed
+// interviews between the two times.
+func ReadEngineerBusySlots(ctx context.Context, engineerID uuid.UUID, from, to time.Time) ([]domain.BusySlot, error) {
+	interviewCol := db.Database.Collection("interview_requests")
+
+	filter := bson.M{
+		"engineer_id": engineerID,
+		"status": bson.M{"$in": bson.A{domain.InterviewStatusPending, domain.InterviewStatusConfirmed}},
+		"start": bson.M{"$lt": to},
+		"end": bson.M{"$gt": from},
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "start", Value: 1}}).
+		SetProjection(bson.M{"start": 1, "end": 1})
+
+	cur, err := interviewCol.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := []domain.BusySlot{}
+	err = cur.All(ctx, &slots)
+	if err != nil {
+		return nil, err
+	}
+
+	return slots, nil
+}
+
+func ReadInterviewRequestsByEngineer(ctx context.Context, engineerID uuid.UUID) ([]*domain.InterviewRequest, error) {
+	return readInterviewRequests(ctx, bson.M{"engineer_id": engineerID})
+}
+
+func ReadInterviewRequestsByRecruiter(ctx context.Context, recruiterID uuid.UUID) ([]*domain.InterviewRequest, error) {
+	return readInterviewRequests(ctx, bson.M{"recruiter_id": recruiterID})
+}
+
+func readInterviewRequests(ctx context.Context, filter bson.M) ([]*domain.InterviewRequest, error) {
+	interviewCol := db.Database.Collection("interview_requests")
+
+	cur, err := interviewCol.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "start", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	interviews := []*domain.InterviewRequest{}
+	err = cur.All(ctx, &interviews)
+	if err != nil {
+		return nil, err
+	}
+
+	return interviews, nil
+}
+
+// FindPendingInterviewRequest returns the engineer's pending interview request.
+func FindPendingInterviewRequest(ctx context.Context, engineerID uuid.UUID, requestID string) (*domain.InterviewRequest, error) {
+	interviewCol := db.Database.Collection("interview_requests")
+	var interview domain.InterviewRequest
+
+	parsedRequestID, err := uuid.Parse(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = interviewCol.FindOne(ctx, bson.M{"_id": parsedRequestID, "engineer_id": engineerID, "status": domain.InterviewStatusPending}).Decode(&interview)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &interview, nil
+}
+
+// RespondInterviewRequest only changes requests that are still pending.
+func RespondInterviewRequest(ctx context.Context, requestID uuid.UUID, status string) (*domain.InterviewRequest, error) {
+	interviewCol := db.Database.Collection("interview_requests")
+	now := time.Now()
+
+	var interview domain.InterviewRequest
+	err := interviewCol.FindOneAndUpdate(ctx,
+		bson.M{"_id": requestID, "status": domain.InterviewStatusPending},
+		bson.M{"$set": bson.M{"status": status, "responded_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&interview)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &interview, nil
+}
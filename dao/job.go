@@ -0,0 +1,204 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func InsertNewJob(ctx context.Context, job *domain.Job) error {
+	jobCol := db.Database.Collection("jobs")
+
+	_, err := jobCol.InsertOne(ctx, job)
+	return err
+}
+
+func FindJobById(ctx context.Context, jobID string) (*domain.Job, error) {
+	jobCol := db.Database.Collection("jobs")
+	var job domain.Job
+
+	parsedJobID, err := uuid.Parse(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = jobCol.FindOne(ctx, bson.M{"_id": parsedJobID}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func UpdateJob(ctx context.Context, jobID string, data *domain.UpdateJobPayload) (*domain.Job, error) {
+	jobCol := db.Database.Collection("jobs")
+
+	parsedJobID, err := uuid.Parse(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	data.UpdatedAt = time.Now()
+
+	var updatedJob *domain.Job
+	err = jobCol.
+		FindOneAndUpdate(ctx, bson.M{"_id": parsedJobID}, bson.M{"$set": data}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updatedJob)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedJob, nil
+}
+
+// UpdateJobStatus moves the job to the status, provided it is still in the status it was read
+// in, so that concurrent transitions cannot both apply.
+func UpdateJobStatus(ctx context.Context, job *domain.Job, status string) (*domain.Job, error) {
+	jobCol := db.Database.Collection("jobs")
+	now := time.Now()
+
+	set := bson.M{"status": status, "updated_at": now}
+	switch status {
+	case domain.JobStatusPublished:
+		set["published_at"] = now
+	case domain.JobStatusClosed:
+		set["closed_at"] = now
+	}
+
+	var updatedJob domain.Job
+	err := jobCol.
+		FindOneAndUpdate(ctx, bson.M{"_id": job.ID, "status": job.Status}, bson.M{"$set": set}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updatedJob)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &updatedJob, nil
+}
+
+func ReadJobsByRecruiter(ctx context.Context, recruiterID uuid.UUID) ([]*domain.Job, error) {
+	return readJobs(ctx, bson.M{"recruiter_id": recruiterID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+}
+
+// ReadPublishedJobs lists published jobs, most recently published first.
+func ReadPublishedJobs(ctx context.Context, listParams *domain.ListJobsParams) ([]*domain.Job, error) {
+	listFilter := listParams.Filter
+	filter := bson.M{"status": domain.JobStatusPublished}
+
+	if listFilter.RoleType != "" {
+		filter["role_type"] = listFilter.RoleType
+	}
+
+	if listFilter.RoleLevel != "" {
+		filter["role_level"] = listFilter.RoleLevel
+	}
+
+	if listFilter.Remote != "" {
+		filter["remote"] = listFilter.Remote
+	}
+
+	if listFilter.Skill != "" {
+		filter["skills"] = listFilter.Skill
+	}
+
+	clauses := bson.A{}
+	if listFilter.MinSalary > 0 {
+		clauses = append(clauses, listFilter.SalaryFilter())
+	}
+
+	if listFilter.Country != "" {
+		countryCode := domain.DefaultCityDataset().CountryCode(listFilter.Country)
+		clauses = append(clauses, bson.M{"$or": bson.A{
+			bson.M{"country": listFilter.Country},
+			bson.M{"country_code": countryCode},
+		}})
+	}
+
+	if listFilter.Query != "" {
+		pattern := caseInsensitiveMatch(listFilter.Query)
+		clauses = append(clauses, bson.M{"$or": bson.A{
+			bson.M{"title": pattern},
+			bson.M{"description": pattern},
+			bson.M{"company": pattern},
+		}})
+	}
+
+	hiddenRecruiterIDs, err := readHiddenRecruiterIDs(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(hiddenRecruiterIDs) > 0 {
+		filter["recruiter_id"] = bson.M{"$nin": hiddenRecruiterIDs}
+	}
+
+	if len(clauses) > 0 {
+		filter["$and"] = clauses
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "published_at", Value: -1}}).
+		SetSkip(listParams.Pagination.Skip()).
+		SetLimit(listParams.Pagination.Limit)
+
+	return readJobs(ctx, filter, findOptions)
+}
+
+// readHiddenRecruiterIDs lists the recruiters currently hidden, whose jobs are left out of the
+// public listing. Few profiles are hidden at a time, unlike the recruiters who aren't.
+func readHiddenRecruiterIDs(ctx context.Context, now time.Time) (bson.A, error) {
+	recruiterCol := db.Database.Collection("recruiters")
+
+	filter := bson.M{"$nor": bson.A{domain.NotHiddenFilter(now)}}
+	cur, err := recruiterCol.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var recruiters []struct {
+		ID uuid.UUID	`bson:"_id"`
+	}
+	err = cur.All(ctx, &recruiters)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := bson.A{}
+	for _, recruiter := range recruiters {
+		ids = append(ids, recruiter.ID)
+	}
+
+	return ids, nil
+}
+
+func readJobs(ctx context.Context, filter bson.M, findOptions *options.FindOptions) ([]*domain.Job, error) {
+	jobCol := db.Database.Collection("jobs")
+
+	cur, err := jobCol.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := []*domain.Job{}
+	err = cur.All(ctx, &jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// caseInsensitiveMatch matches the text literally and case-insensitively.
+func caseInsensitiveMatch(text string) bson.M {
+	return bson.M{"$regex": regexp.QuoteMeta(text), "$options": "i"}
+}
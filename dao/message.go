@@ -0,0 +1,196 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureConversation creates the conversation unless the pair already has one, and returns the stored thread.
+func EnsureConversation(ctx context.Context, conversation *domain.Conversation) (*domain.Conversation, error) {
+	conversationCol := db.Database.Collection("conversations")
+
+	_, err := conversationCol.UpdateOne(ctx, bson.M{"_id": conversation.ID}, bson.M{"$setOnInsert": conversation}, options.Update().SetUpsert(true))
+	if err != nil {
+		return nil, err
+	}
+
+	return FindConversationForUser(ctx, conversation.ID.String(), conversation.RecruiterUserID)
+}
+
+// FindConversationForUser only returns the conversation to one of its two participants.
+func FindConversationForUser(ctx context.Context, conversationID string, userID uuid.UUID) (*domain.Conversation, error) {
+	conversationCol := db.Database.Collection("conversations")
+	var conversation domain.Conversation
+
+	parsedConversationID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = conversationCol.FindOne(ctx, bson.M{"_id": parsedConversationID, "participant_ids": userID}).Decode(&conversation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &conversation, nil
+}
+
+func ReadConversationsByUser(ctx context.Context, userID uuid.UUID, pagination *domain.Pagination) ([]*domain.Conversation, error) {
+	conversationCol := db.Database.Collection("conversations")
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "last_message_at", Value: -1}}).
+		SetSkip(pagination.Skip()).
+		SetLimit(pagination.Limit)
+
+	cur, err := conversationCol.Find(ctx, bson.M{"participant_ids": userID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	conversations := []*domain.Conversation{}
+	err = cur.All(ctx, &conversations)
+	if err != nil {
+		return nil, err
+	}
+
+	return conversations, nil
+}
+
+// InsertMessage stores the message and bumps the thread's last activity and the recipient's unread count.
+func InsertMessage(ctx context.Context, message *domain.Message) error {
+	messageCol := db.Database.Collection("messages")
+	conversationCol := db.Database.Collection("conversations")
+
+	_, err := messageCol.InsertOne(ctx, message)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{"last_message_at": message.CreatedAt, "last_message_preview": message.Preview()},
+		"$inc": bson.M{"unread_counts." + message.RecipientID.String(): 1},
+	}
+	_, err = conversationCol.UpdateOne(ctx, bson.M{"_id": message.ConversationID}, update)
+	return err
+}
+
+// ReadMessages returns the newest messages first.
+func ReadMessages(ctx context.Context, conversationID uuid.UUID, pagination *domain.Pagination) ([]*domain.Message, error) {
+	messageCol := db.Database.Collection("messages")
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(pagination.Skip()).
+		SetLimit(pagination.Limit)
+
+	cur, err := messageCol.Find(ctx, bson.M{"conversation_id": conversationID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []*domain.Message{}
+	err = cur.All(ctx, &messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// MarkConversationRead sets the read receipt of every message the user received in the thread.
+func MarkConversationRead(ctx context.Context, conversationID, userID uuid.UUID) (int64, error) {
+	messageCol := db.Database.Collection("messages")
+	conversationCol := db.Database.Collection("conversations")
+
+	res, err := messageCol.UpdateMany(ctx,
+		bson.M{"conversation_id": conversationID, "recipient_id": userID, "read_at": nil},
+		bson.M{"$set": bson.M{"read_at": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = conversationCol.UpdateOne(ctx, bson.M{"_id": conversationID}, bson.M{"$set": bson.M{"unread_counts." + userID.String(): 0}})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.ModifiedCount, nil
+}
+
+func CountUnreadMessages(ctx context.Context, userID uuid.UUID) (int64, error) {
+	messageCol := db.Database.Collection("messages")
+
+	return messageCol.CountDocuments(ctx, bson.M{"recipient_id": userID, "read_at": nil})
+}
+
+// ReadMessagesToNotify returns unread messages older than the given time that no email mentioned yet.
+func ReadMessagesToNotify(ctx context.Context, createdBefore time.Time) ([]*domain.Message, error) {
+	messageCol := db.Database.Collection("messages")
+
+	filter := bson.M{"read_at": nil, "notified_at": nil, "created_at": bson.M{"$lte": createdBefore}}
+	cur, err := messageCol.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*domain.Message
+	err = cur.All(ctx, &messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func MarkMessagesNotified(ctx context.Context, messageIDs []uuid.UUID) error {
+	messageCol := db.Database.Collection("messages")
+
+	_, err := messageCol.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": messageIDs}}, bson.M{"$set": bson.M{"notified_at": time.Now()}})
+	return err
+}
+
+func InsertBlock(ctx context.Context, block *domain.Block) error {
+	blockCol := db.Database.Collection("blocks")
+
+	_, err := blockCol.UpdateOne(ctx, bson.M{"_id": block.ID}, bson.M{"$setOnInsert": block}, options.Update().SetUpsert(true))
+	return err
+}
+
+func DeleteBlock(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	blockCol := db.Database.Collection("blocks")
+
+	res, err := blockCol.DeleteOne(ctx, bson.M{"blocker_id": blockerID, "blocked_id": blockedID})
+	if err != nil {
+		return false, err
+	}
+
+	return res.DeletedCount == 1, nil
+}
+
+// IsBlocked tells whether either user blocked the other.
+func IsBlocked(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error) {
+	blockCol := db.Database.Collection("blocks")
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"blocker_id": userID, "blocked_id": otherUserID},
+		bson.M{"blocker_id": otherUserID, "blocked_id": userID},
+	}}
+	count, err := blockCol.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return count != 0, nil
+}
@@ -0,0 +1,204 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertModerationFlags queues flags raised by the moderation pipeline. A field already flagged
+// for the same reason and still waiting for review isn't queued again, so that editing a flagged
+// bio doesn't pile up flags.
+func InsertModerationFlags(ctx context.Context, flags []*domain.ModerationFlag) error {
+	flagCol := db.Database.Collection("moderation_flags")
+
+	for _, flag := range flags {
+		filter := bson.M{
+			"profile_id": flag.ProfileID,
+			"source": flag.Source,
+			"field": flag.Field,
+			"reason": flag.Reason,
+			"status": domain.ModerationFlagOpen,
+		}
+		_, err := flagCol.UpdateOne(ctx, filter, bson.M{"$setOnInsert": flag}, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InsertDuplicateFlags queues flags raised by the duplicate detection. A profile isn't flagged
+// again for the same set of duplicates once an admin reviewed it, only when the set changes. It
+// returns how many flags it queued.
+func InsertDuplicateFlags(ctx context.Context, flags []*domain.ModerationFlag) (int, error) {
+	flagCol := db.Database.Collection("moderation_flags")
+	queued := 0
+
+	for _, flag := range flags {
+		filter := bson.M{
+			"profile_id": flag.ProfileID,
+			"source": flag.Source,
+			"field": flag.Field,
+			"details": flag.Details,
+		}
+		result, err := flagCol.UpdateOne(ctx, filter, bson.M{"$setOnInsert": flag}, options.Update().SetUpsert(true))
+		if err != nil {
+			return queued, err
+		}
+		queued += int(result.UpsertedCount)
+	}
+
+	return queued, nil
+}
+
+// InsertReport stores the report unless the reporter already has an open report on the profile,
+// in which case that one is returned instead.
+func InsertReport(ctx context.Context, report *domain.ModerationFlag) (*domain.ModerationFlag, error) {
+	flagCol := db.Database.Collection("moderation_flags")
+	var stored domain.ModerationFlag
+
+	filter := bson.M{
+		"profile_id": report.ProfileID,
+		"source": domain.ModerationSourceReport,
+		"reporter_id": report.ReporterID,
+		"status": domain.ModerationFlagOpen,
+	}
+	findOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	err := flagCol.FindOneAndUpdate(ctx, filter, bson.M{"$setOnInsert": report}, findOptions).Decode(&stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stored, nil
+}
+
+// CountOpenReports counts the users whose report on the profile is waiting for review.
+func CountOpenReports(ctx context.Context, profileID uuid.UUID) (int64, error) {
+	filter := bson.M{"profile_id": profileID, "source": domain.ModerationSourceReport, "status": domain.ModerationFlagOpen}
+	return db.Database.Collection("moderation_flags").CountDocuments(ctx, filter)
+}
+
+// ReadModerationFlags lists the flags with the status, the oldest first so that the queue is
+// worked through in order.
+func ReadModerationFlags(ctx context.Context, status string, pagination *domain.Pagination) ([]*domain.ModerationFlag, error) {
+	flagCol := db.Database.Collection("moderation_flags")
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetSkip(pagination.Skip()).
+		SetLimit(pagination.Limit)
+
+	cur, err := flagCol.Find(ctx, bson.M{"status": status}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := []*domain.ModerationFlag{}
+	err = cur.All(ctx, &flags)
+	if err != nil {
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+// ReviewModerationFlag closes an open flag. It returns nil when there is no such open flag.
+func ReviewModerationFlag(ctx context.Context, flagID, reviewerID uuid.UUID, review *domain.ReviewModerationFlagPayload, reviewedAt time.Time) (*domain.ModerationFlag, error) {
+	flagCol := db.Database.Collection("moderation_flags")
+	var flag domain.ModerationFlag
+
+	filter := bson.M{"_id": flagID, "status": domain.ModerationFlagOpen}
+	update := bson.M{"$set": bson.M{
+		"status": review.Status,
+		"note": review.Note,
+		"reviewed_at": reviewedAt,
+		"reviewed_by": reviewerID,
+	}}
+
+	err := flagCol.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&flag)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &flag, nil
+}
+
+// ReviewOpenReports closes the reports on the profile still waiting for review, the way the admin
+// reviewed one of them, and returns them.
+func ReviewOpenReports(ctx context.Context, profileID, reviewerID uuid.UUID, review *domain.ReviewModerationFlagPayload, reviewedAt time.Time) ([]*domain.ModerationFlag, error) {
+	flagCol := db.Database.Collection("moderation_flags")
+
+	filter := bson.M{"profile_id": profileID, "source": domain.ModerationSourceReport, "status": domain.ModerationFlagOpen}
+	cur, err := flagCol.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []*domain.ModerationFlag{}
+	err = cur.All(ctx, &reports)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reports) == 0 {
+		return reports, nil
+	}
+
+	ids := bson.A{}
+	for _, report := range reports {
+		ids = append(ids, report.ID)
+		report.Status = review.Status
+		report.Note = review.Note
+		report.ReviewedAt = reviewedAt
+		report.ReviewedBy = &reviewerID
+	}
+
+	_, err = flagCol.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}, "status": domain.ModerationFlagOpen}, bson.M{"$set": bson.M{
+		"status": review.Status,
+		"note": review.Note,
+		"reviewed_at": reviewedAt,
+		"reviewed_by": reviewerID,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// HideProfile hides the engineer or recruiter, per profileType, replacing any hide in place.
+func HideProfile(ctx context.Context, profileType string, profileID uuid.UUID, hide *domain.ProfileHide) error {
+	_, err := db.Database.Collection(profileCollections[profileType]).UpdateOne(ctx, bson.M{"_id": profileID}, bson.M{"$set": bson.M{"hidden": hide}})
+	return err
+}
+
+// HideReportedProfile hides the profile unless it is hidden already, so that further reports
+// neither extend the hide nor shorten one an admin decided on. It returns whether it hid it.
+func HideReportedProfile(ctx context.Context, profileType string, profileID uuid.UUID, hide *domain.ProfileHide) (bool, error) {
+	filter := bson.M{"$and": bson.A{bson.M{"_id": profileID}, domain.NotHiddenFilter(hide.At)}}
+	result, err := db.Database.Collection(profileCollections[profileType]).UpdateOne(ctx, filter, bson.M{"$set": bson.M{"hidden": hide}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// UnhideProfile lifts the hide of the profile if it was put in place for the reason.
+func UnhideProfile(ctx context.Context, profileType string, profileID uuid.UUID, reason string) error {
+	filter := bson.M{"_id": profileID, "hidden.reason": reason}
+	_, err := db.Database.Collection(profileCollections[profileType]).UpdateOne(ctx, filter, bson.M{"$unset": bson.M{"hidden": ""}})
+	return err
+}
@@ -0,0 +1,129 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func InsertNotification(ctx context.Context, notification *domain.Notification) error {
+	notificationCol := db.Database.Collection("notifications")
+
+	_, err := notificationCol.InsertOne(ctx, notification)
+	return err
+}
+
+func ReadNotificationsByUser(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination *domain.Pagination) ([]*domain.Notification, error) {
+	notificationCol := db.Database.Collection("notifications")
+
+	filter := bson.M{"user_id": userID}
+	if unreadOnly {
+		filter["read_at"] = nil
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(pagination.Skip()).
+		SetLimit(pagination.Limit)
+
+	cur, err := notificationCol.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := []*domain.Notification{}
+	err = cur.All(ctx, &notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int64, error) {
+	notificationCol := db.Database.Collection("notifications")
+
+	return notificationCol.CountDocuments(ctx, bson.M{"user_id": userID, "read_at": nil})
+}
+
+// MarkNotificationsRead marks the given notifications of the user as read, or all of them
+// when no id is given.
+func MarkNotificationsRead(ctx context.Context, userID uuid.UUID, notificationIDs []uuid.UUID) (int64, error) {
+	notificationCol := db.Database.Collection("notifications")
+
+	filter := bson.M{"user_id": userID, "read_at": nil}
+	if len(notificationIDs) > 0 {
+		filter["_id"] = bson.M{"$in": notificationIDs}
+	}
+
+	res, err := notificationCol.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"read_at": time.Now()}})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.ModifiedCount, nil
+}
+
+// ChangeStreamBroker delivers notifications inserted by any instance of the API by watching
+// the notifications collection, which requires mongo to run as a replica set.
+type ChangeStreamBroker struct {
+	local *domain.InProcessBroker
+}
+
+func NewChangeStreamBroker(ctx context.Context) *ChangeStreamBroker {
+	b := &ChangeStreamBroker{local: domain.NewInProcessBroker()}
+	go b.watch(ctx)
+	return b
+}
+
+// Publish is a no-op: the insert itself reaches every instance through the change stream.
+func (b *ChangeStreamBroker) Publish(notification *domain.Notification) {}
+
+func (b *ChangeStreamBroker) Subscribe(userID uuid.UUID) (<-chan *domain.Notification, func()) {
+	return b.local.Subscribe(userID)
+}
+
+func (b *ChangeStreamBroker) watch(ctx context.Context) {
+	notificationCol := db.Database.Collection("notifications")
+	pipeline := mongo.Pipeline{bson.D{{Key: "$match", Value: bson.M{"operationType": "insert"}}}}
+	var resumeToken bson.Raw
+
+	for ctx.Err() == nil {
+		streamOptions := options.ChangeStream()
+		if resumeToken != nil {
+			streamOptions.SetResumeAfter(resumeToken)
+		}
+
+		stream, err := notificationCol.Watch(ctx, pipeline, streamOptions)
+		if err != nil {
+			internal.LogInfo("Failed to watch notifications", map[string]interface{}{"error": err.Error()})
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		for stream.Next(ctx) {
+			var event struct {
+				FullDocument domain.Notification `bson:"fullDocument"`
+			}
+			if err := stream.Decode(&event); err != nil {
+				internal.LogInfo("Failed to decode notification event", map[string]interface{}{"error": err.Error()})
+				continue
+			}
+
+			resumeToken = stream.ResumeToken()
+			b.local.Publish(&event.FullDocument)
+		}
+
+		if err := stream.Err(); err != nil {
+			internal.LogInfo("Notification stream interrupted", map[string]interface{}{"error": err.Error()})
+		}
+		stream.Close(context.Background())
+	}
+}
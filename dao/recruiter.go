@@ -5,6 +5,7 @@ import (
 	"errors"
 	"angular-talents-backend/db"
 	"angular-talents-backend/domain"
+	"time"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
@@ -16,6 +17,7 @@ import (
 func InsertNewRecruiter(ctx context.Context, recruiter *domain.Recruiter) (string, error) {
 	recruiterCol := db.Database.Collection("recruiters")
 
+	recruiter.CompletenessScore = recruiter.Completeness().Score
 	insertResult, err := recruiterCol.InsertOne(ctx, *recruiter)
 	if err != nil {
 		return "",  err
@@ -72,6 +74,42 @@ func FindRecruiterByUser(ctx context.Context, userID uuid.UUID) (*domain.Recruit
 }
 
 func UpdateRecruiter(ctx context.Context, recruiterID string, data *domain.UpdateRecruiterPayload) (*domain.Recruiter, error)  {
+	parsedRecruiterID, err := uuid.Parse(recruiterID)
+	if err != nil {
+		return nil, err
+	}
+
+	data.UpdatedAt = time.Now()
+
+	return updateRecruiterWithHistory(ctx, bson.M{"_id": parsedRecruiterID}, bson.M{"$set": data})
+}
+
+func UpdateRecruiterByUser(ctx context.Context, userID uuid.UUID, data *domain.UpdateRecruiterPayload) (*domain.Recruiter, error)  {
+	data.UpdatedAt = time.Now()
+
+	return updateRecruiterWithHistory(ctx, bson.M{"user_id": userID}, bson.M{"$set": data})
+}
+
+// updateRecruiterWithHistory fails with mongo.ErrNoDocuments when no recruiter matches.
+func updateRecruiterWithHistory(ctx context.Context, filter, update bson.M) (*domain.Recruiter, error) {
+	var updatedRecruiter domain.Recruiter
+	found, err := updateProfileWithHistory(ctx, domain.ProfileTypeRecruiter, filter, update, nil, &updatedRecruiter)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	err = storeRecruiterCompleteness(ctx, &updatedRecruiter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedRecruiter, nil
+}
+
+func UpdateRecruiterMembership(ctx context.Context, recruiterID string, isMember bool) (*domain.Recruiter, error) {
 	recruiterCol := db.Database.Collection("recruiters")
 
 	parsedRecruiterID, err := uuid.Parse(recruiterID)
@@ -79,25 +117,37 @@ func UpdateRecruiter(ctx context.Context, recruiterID string, data *domain.Updat
 		return nil, err
 	}
 
-	var updatedRecruiter *domain.Recruiter
-	 err = recruiterCol.
-	FindOneAndUpdate(ctx, bson.M{"_id": parsedRecruiterID}, bson.M{"$set": data}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updatedRecruiter)
+	var updatedRecruiter domain.Recruiter
+	err = recruiterCol.
+		FindOneAndUpdate(ctx, bson.M{"_id": parsedRecruiterID}, bson.M{"$set": bson.M{"is_member": isMember}}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updatedRecruiter)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
 		return nil, err
 	}
 
-	return updatedRecruiter, nil
+	return &updatedRecruiter, nil
 }
 
-func UpdateRecruiterByUser(ctx context.Context, userID uuid.UUID, data *domain.UpdateRecruiterPayload) (*domain.Recruiter, error)  {
+// ReplaceRecruiterLogo returns nil when the user has no recruiter profile.
+func ReplaceRecruiterLogo(ctx context.Context, userID uuid.UUID, logoURL string, image *domain.UploadedImage) (*domain.Recruiter, error) {
 	recruiterCol := db.Database.Collection("recruiters")
 
-	var updatedRecruiter *domain.Recruiter
-	 err := recruiterCol.
-	FindOneAndUpdate(ctx, bson.M{"user_id": userID}, bson.M{"$set": data}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updatedRecruiter)
+	var updatedRecruiter domain.Recruiter
+	err := recruiterCol.
+		FindOneAndUpdate(ctx, bson.M{"user_id": userID}, imageUpdate(bson.M{"updated_at": time.Now()}, "logo", "logo_image", logoURL, image), options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updatedRecruiter)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	err = storeRecruiterCompleteness(ctx, &updatedRecruiter)
 	if err != nil {
 		return nil, err
 	}
 
-	return updatedRecruiter, nil
-}
\ No newline at end of file
+	return &updatedRecruiter, nil
+}
@@ -0,0 +1,93 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func InsertNewSavedSearch(ctx context.Context, search *domain.SavedSearch) error {
+	searchCol := db.Database.Collection("saved_searches")
+
+	_, err := searchCol.InsertOne(ctx, search)
+	return err
+}
+
+func ReadSavedSearchesByRecruiter(ctx context.Context, recruiterID uuid.UUID) ([]*domain.SavedSearch, error) {
+	searchCol := db.Database.Collection("saved_searches")
+
+	cur, err := searchCol.Find(ctx, bson.M{"recruiter_id": recruiterID})
+	if err != nil {
+		return nil, err
+	}
+
+	searches := []*domain.SavedSearch{}
+	err = cur.All(ctx, &searches)
+	if err != nil {
+		return nil, err
+	}
+
+	return searches, nil
+}
+
+// DeleteSavedSearch only deletes the search if it belongs to the recruiter, and reports whether it did.
+func DeleteSavedSearch(ctx context.Context, recruiterID uuid.UUID, searchID string) (bool, error) {
+	searchCol := db.Database.Collection("saved_searches")
+
+	parsedSearchID, err := uuid.Parse(searchID)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := searchCol.DeleteOne(ctx, bson.M{"_id": parsedSearchID, "recruiter_id": recruiterID})
+	if err != nil {
+		return false, err
+	}
+
+	return res.DeletedCount == 1, nil
+}
+
+// ReadDueSavedSearches returns the searches whose digest period has elapsed.
+func ReadDueSavedSearches(ctx context.Context, now time.Time) ([]*domain.SavedSearch, error) {
+	searchCol := db.Database.Collection("saved_searches")
+
+	due := bson.A{}
+	for frequency, period := range domain.SearchFrequencyPeriods {
+		due = append(due, bson.M{"frequency": frequency, "last_run_at": bson.M{"$lte": now.Add(-period)}})
+	}
+	filter := bson.M{"$or": due}
+
+	cur, err := searchCol.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var searches []*domain.SavedSearch
+	err = cur.All(ctx, &searches)
+	if err != nil {
+		return nil, err
+	}
+
+	return searches, nil
+}
+
+// UpdateSavedSearchRun records the run and the engineers it notified, keeping only the most
+// recently notified ones.
+func UpdateSavedSearchRun(ctx context.Context, searchID uuid.UUID, lastRunAt time.Time, notifiedEngineerIDs []uuid.UUID) error {
+	searchCol := db.Database.Collection("saved_searches")
+
+	update := bson.M{"$set": bson.M{"last_run_at": lastRunAt}}
+	if len(notifiedEngineerIDs) > 0 {
+		update["$push"] = bson.M{"notified_engineer_ids": bson.M{
+			"$each": notifiedEngineerIDs,
+			"$slice": -domain.MaxNotifiedEngineerIDs,
+		}}
+	}
+
+	_, err := searchCol.UpdateOne(ctx, bson.M{"_id": searchID}, update)
+	return err
+}
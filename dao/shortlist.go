@@ -0,0 +1,132 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func InsertNewShortlist(ctx context.Context, shortlist *domain.Shortlist) error {
+	shortlistCol := db.Database.Collection("shortlists")
+
+	_, err := shortlistCol.InsertOne(ctx, shortlist)
+	return err
+}
+
+func ReadShortlistsByRecruiter(ctx context.Context, recruiterID uuid.UUID) ([]*domain.Shortlist, error) {
+	shortlistCol := db.Database.Collection("shortlists")
+
+	cur, err := shortlistCol.Find(ctx, bson.M{"recruiter_id": recruiterID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	shortlists := []*domain.Shortlist{}
+	err = cur.All(ctx, &shortlists)
+	if err != nil {
+		return nil, err
+	}
+
+	return shortlists, nil
+}
+
+// FindShortlist returns the shortlist only if it belongs to the recruiter.
+func FindShortlist(ctx context.Context, recruiterID uuid.UUID, shortlistID string) (*domain.Shortlist, error) {
+	shortlistCol := db.Database.Collection("shortlists")
+	var shortlist domain.Shortlist
+
+	parsedShortlistID, err := uuid.Parse(shortlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = shortlistCol.FindOne(ctx, bson.M{"_id": parsedShortlistID, "recruiter_id": recruiterID}).Decode(&shortlist)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &shortlist, nil
+}
+
+func RenameShortlist(ctx context.Context, recruiterID, shortlistID uuid.UUID, name string) (*domain.Shortlist, error) {
+	return updateShortlist(ctx, bson.M{"_id": shortlistID, "recruiter_id": recruiterID}, bson.M{"$set": bson.M{"name": name, "updated_at": time.Now()}})
+}
+
+func DeleteShortlist(ctx context.Context, recruiterID uuid.UUID, shortlistID string) (bool, error) {
+	shortlistCol := db.Database.Collection("shortlists")
+
+	parsedShortlistID, err := uuid.Parse(shortlistID)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := shortlistCol.DeleteOne(ctx, bson.M{"_id": parsedShortlistID, "recruiter_id": recruiterID})
+	if err != nil {
+		return false, err
+	}
+
+	return res.DeletedCount == 1, nil
+}
+
+func AddShortlistEntry(ctx context.Context, recruiterID, shortlistID uuid.UUID, entry *domain.ShortlistEntry) (*domain.Shortlist, error) {
+	filter := bson.M{"_id": shortlistID, "recruiter_id": recruiterID, "entries.engineer_id": bson.M{"$ne": entry.EngineerID}}
+	update := bson.M{
+		"$push": bson.M{"entries": entry},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	return updateShortlist(ctx, filter, update)
+}
+
+func UpdateShortlistEntry(ctx context.Context, recruiterID, shortlistID, engineerID uuid.UUID, data *domain.UpdateShortlistEntryPayload) (*domain.Shortlist, error) {
+	now := time.Now()
+	set := bson.M{"updated_at": now, "entries.$.updated_at": now}
+	if data.Notes != nil {
+		set["entries.$.notes"] = *data.Notes
+	}
+	if data.Tags != nil {
+		set["entries.$.tags"] = *data.Tags
+	}
+	if data.Stage != "" {
+		set["entries.$.stage"] = data.Stage
+	}
+
+	filter := bson.M{"_id": shortlistID, "recruiter_id": recruiterID, "entries.engineer_id": engineerID}
+	return updateShortlist(ctx, filter, bson.M{"$set": set})
+}
+
+func RemoveShortlistEntry(ctx context.Context, recruiterID, shortlistID, engineerID uuid.UUID) (*domain.Shortlist, error) {
+	filter := bson.M{"_id": shortlistID, "recruiter_id": recruiterID, "entries.engineer_id": engineerID}
+	update := bson.M{
+		"$pull": bson.M{"entries": bson.M{"engineer_id": engineerID}},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	return updateShortlist(ctx, filter, update)
+}
+
+// updateShortlist returns nil when no shortlist matched the filter.
+func updateShortlist(ctx context.Context, filter, update bson.M) (*domain.Shortlist, error) {
+	shortlistCol := db.Database.Collection("shortlists")
+
+	var updatedShortlist *domain.Shortlist
+	err := shortlistCol.
+		FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updatedShortlist)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return updatedShortlist, nil
+}
@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func FindEngineerSlug(ctx context.Context, slug string) (*domain.EngineerSlug, error) {
+	slugCol := db.Database.Collection("engineer_slugs")
+
+	var engineerSlug domain.EngineerSlug
+	err := slugCol.FindOne(ctx, bson.M{"_id": slug}).Decode(&engineerSlug)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &engineerSlug, nil
+}
+
+// ClaimEngineerSlug makes the slug the engineer's current one. Engineers may go back to a slug
+// they held before, but not take one held by someone else, which fails with domain.ErrSlugTaken.
+func ClaimEngineerSlug(ctx context.Context, engineer *domain.Engineer, slug string) (*domain.Engineer, error) {
+	slugCol := db.Database.Collection("engineer_slugs")
+
+	if engineer.Slug == slug {
+		return engineer, nil
+	}
+
+	existing, err := FindEngineerSlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil && existing.EngineerID != engineer.ID {
+		return nil, domain.ErrSlugTaken
+	}
+
+	if existing == nil {
+		count, err := slugCol.CountDocuments(ctx, bson.M{"engineer_id": engineer.ID})
+		if err != nil {
+			return nil, err
+		}
+		if count >= domain.MaxEngineerSlugs {
+			return nil, domain.ErrTooManySlugs
+		}
+
+		_, err = slugCol.InsertOne(ctx, domain.EngineerSlug{Slug: slug, EngineerID: engineer.ID, ClaimedAt: time.Now()})
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.ErrSlugTaken
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return findAndUpdateEngineer(ctx, bson.M{"_id": engineer.ID}, bson.M{"$set": bson.M{"slug": slug, "updated_at": time.Now()}})
+}
+
+// ReadSitemapEngineers returns the engineers matching the filter, with only the fields a
+// sitemap needs.
+func ReadSitemapEngineers(ctx context.Context, filter bson.M) ([]*domain.Engineer, error) {
+	engCol := db.Database.Collection("engineers")
+
+	findOptions := options.Find().
+		SetProjection(bson.M{"_id": 1, "slug": 1, "updated_at": 1}).
+		SetSort(bson.D{{Key: "updated_at", Value: -1}}).
+		SetLimit(domain.MaxSitemapURLs)
+
+	cur, err := engCol.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	engineers := []*domain.Engineer{}
+	err = cur.All(ctx, &engineers)
+	if err != nil {
+		return nil, err
+	}
+
+	return engineers, nil
+}
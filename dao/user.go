@@ -86,3 +86,34 @@ func FindUserById(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
 
 	return &user, nil
 }
+
+// normalizedEmailBackfillBatch bounds the users updated per run for accounts created before
+// emails were normalized.
+const normalizedEmailBackfillBatch = 500
+
+// BackfillNormalizedEmails stores the normalized email of a batch of the users who have none yet,
+// so that signing up with an alias of their address is refused. It returns how many it updated.
+func BackfillNormalizedEmails(ctx context.Context) (int, error) {
+	userCol := db.Database.Collection("users")
+
+	filter := bson.M{"normalized_email": bson.M{"$exists": false}}
+	cur, err := userCol.Find(ctx, filter, options.Find().SetLimit(normalizedEmailBackfillBatch))
+	if err != nil {
+		return 0, err
+	}
+
+	users := []*domain.User{}
+	err = cur.All(ctx, &users)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, user := range users {
+		_, err := userCol.UpdateOne(ctx, bson.M{"_id": user.ID}, bson.M{"$set": bson.M{"normalized_email": domain.NormalizeEmail(user.Email)}})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(users), nil
+}
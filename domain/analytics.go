@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const statsDayLayout = "2006-01-02"
+
+const (
+	DefaultStatsDays = 30
+	MaxStatsDays     = 365
+)
+
+// ProfileView is recorded at most once per viewer, engineer and UTC day. Anonymous viewers are
+// keyed by a hash of their IP address and user agent so that neither is stored.
+type ProfileView struct {
+	ID uuid.UUID				`bson:"_id,required"`
+	EngineerID uuid.UUID		`bson:"engineer_id,required"`
+	ViewerKey string			`bson:"viewer_key,required"`
+	Day string					`bson:"day,required"`
+	IsMember bool				`bson:"is_member"`
+	RecruiterID *uuid.UUID		`bson:"recruiter_id,omitempty"`
+	Company string				`bson:"company,omitempty"`
+	CreatedAt time.Time			`bson:"created_at,required"`
+}
+
+type DailyCount struct {
+	Day string		`bson:"_id"`
+	Count int64		`bson:"count"`
+}
+
+type CompanyViews struct {
+	Company string		`bson:"_id"`
+	Views int64			`bson:"views"`
+}
+
+type EngineerStats struct {
+	From string
+	To string
+	TotalViews int64
+	UniqueViewers int64
+	MemberViews int64
+	SearchAppearances int64
+	DailyViews []DailyCount
+	DailySearchAppearances []DailyCount
+	ViewerCompanies []CompanyViews
+}
+
+// NewProfileView returns nil when the view should not be counted, i.e. when owners look at
+// their own profile.
+func NewProfileView(ctx context.Context, engineer *Engineer, remoteIP, userAgent string, now time.Time) *ProfileView {
+	viewer := NewViewer(ctx)
+	if viewer.Owns(engineer) {
+		return nil
+	}
+
+	view := &ProfileView{
+		EngineerID: engineer.ID,
+		Day: StatsDay(now),
+		IsMember: viewer.IsMember,
+		CreatedAt: now,
+	}
+
+	if viewer.IsAuthenticated() {
+		view.ViewerKey = viewer.UserID.String()
+	} else {
+		hash := sha256.Sum256([]byte(remoteIP + "|" + userAgent))
+		view.ViewerKey = "anonymous:" + hex.EncodeToString(hash[:])
+	}
+
+	if recruiter, ok := ctx.Value("recruiter").(*Recruiter); ok && recruiter.IsMember && !recruiter.HideViewActivity {
+		view.RecruiterID = &recruiter.ID
+		view.Company = recruiter.Company
+	}
+
+	viewHash := md5.Sum([]byte(engineer.ID.String() + view.ViewerKey + view.Day))
+	view.ID, _ = uuid.FromBytes(viewHash[:])
+
+	return view
+}
+
+func StatsDay(t time.Time) string {
+	return t.UTC().Format(statsDayLayout)
+}
+
+// FillDailyCounts returns one entry per day of the range, including days without any count.
+func FillDailyCounts(counts []DailyCount, from, to time.Time) []DailyCount {
+	byDay := map[string]int64{}
+	for _, count := range counts {
+		byDay[count.Day] = count.Count
+	}
+
+	filled := []DailyCount{}
+	for day := from.UTC().Truncate(24 * time.Hour); !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := StatsDay(day)
+		filled = append(filled, DailyCount{Day: key, Count: byDay[key]})
+	}
+
+	return filled
+}
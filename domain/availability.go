@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const blackoutDateLayout = "2006-01-02"
+
+var ErrSlotUnavailable = errors.New("slot unavailable")
+
+// ErrSlotBusy is returned when another booking for the same engineer or recruiter is in progress.
+var ErrSlotBusy = errors.New("another booking is in progress, try again")
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// AvailabilityWindow is a weekly recurring range of local times, e.g. mondays 09:00 to 12:30.
+type AvailabilityWindow struct {
+	Weekday string	`bson:"weekday" json:"weekday" validate:"required,oneof=monday tuesday wednesday thursday friday saturday sunday"`
+	Start string	`bson:"start" json:"start" validate:"required,datetime=15:04"`
+	End string		`bson:"end" json:"end" validate:"required,datetime=15:04"`
+}
+
+// Availability is stored under the engineer's id. Windows and blackout dates are expressed in
+// the engineer's timezone.
+type Availability struct {
+	EngineerID uuid.UUID				`bson:"_id,required"`
+	Timezone string						`bson:"timezone,required"`
+	Windows []AvailabilityWindow		`bson:"windows"`
+	BlackoutDates []string				`bson:"blackout_dates,omitempty"`
+	UpdatedAt time.Time					`bson:"updated_at,required"`
+}
+
+type UpdateAvailabilityPayload struct {
+	Timezone string					`json:"timezone" validate:"required,timezone"`
+	Windows []AvailabilityWindow	`json:"windows" validate:"required,max=50,dive"`
+	BlackoutDates []string			`json:"blackoutDates,omitempty" validate:"omitempty,max=366,dive,datetime=2006-01-02"`
+}
+
+// BusySlot is a time range already taken by an interview, without any detail about it.
+type BusySlot struct {
+	Start time.Time
+	End time.Time
+}
+
+func (p *UpdateAvailabilityPayload) Validate() error {
+	v := validator.New()
+	err := v.Struct(p)
+	if err != nil {
+		return err
+	}
+
+	for _, window := range p.Windows {
+		if windowMinutes(window.End) <= windowMinutes(window.Start) {
+			return fmt.Errorf("window on %s must end after it starts", window.Weekday)
+		}
+	}
+
+	return nil
+}
+
+func (p *UpdateAvailabilityPayload) NewAvailability(engineerID uuid.UUID) *Availability {
+	blackoutDates := []string{}
+	seen := map[string]bool{}
+	for _, date := range p.BlackoutDates {
+		if !seen[date] {
+			seen[date] = true
+			blackoutDates = append(blackoutDates, date)
+		}
+	}
+
+	return &Availability{
+		EngineerID: engineerID,
+		Timezone: p.Timezone,
+		Windows: p.Windows,
+		BlackoutDates: blackoutDates,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// CheckSlot returns ErrSlotUnavailable, wrapped with the reason, unless the slot falls on a
+// single local day that is not blacked out and fits entirely within one window.
+func (a *Availability) CheckSlot(start, end time.Time) error {
+	loc, err := time.LoadLocation(a.Timezone)
+	if err != nil {
+		return err
+	}
+
+	localStart := start.In(loc)
+	localEnd := end.In(loc)
+	if localStart.Format(blackoutDateLayout) != localEnd.Add(-time.Nanosecond).Format(blackoutDateLayout) {
+		return fmt.Errorf("%w: the slot spans two days in %s", ErrSlotUnavailable, a.Timezone)
+	}
+
+	day := localStart.Format(blackoutDateLayout)
+	for _, blackout := range a.BlackoutDates {
+		if blackout == day {
+			return fmt.Errorf("%w: %s is a blackout date", ErrSlotUnavailable, day)
+		}
+	}
+
+	startMinutes := localStart.Hour()*60 + localStart.Minute()
+	endMinutes := startMinutes + int(end.Sub(start)/time.Minute)
+	for _, window := range a.Windows {
+		if weekdays[window.Weekday] != localStart.Weekday() {
+			continue
+		}
+		if windowMinutes(window.Start) <= startMinutes && endMinutes <= windowMinutes(window.End) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s %s is outside the availability windows", ErrSlotUnavailable, strings.ToLower(localStart.Weekday().String()), localStart.Format("15:04"))
+}
+
+func windowMinutes(value string) int {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"strings"
+)
+
+// NormalizeBlockedCompany turns a blocklist entry into the form it is stored and matched in.
+// Entries that look like a domain ("acme.com", "@acme.com") are kept as lowercase domains,
+// anything else is treated as a company name and compared without case or punctuation.
+func NormalizeBlockedCompany(entry string) string {
+	entry = strings.ToLower(strings.TrimSpace(entry))
+	entry = strings.TrimPrefix(entry, "@")
+
+	if strings.Contains(entry, ".") && !strings.ContainsAny(entry, " \t") {
+		return entry
+	}
+
+	return normalizePlace(entry)
+}
+
+// NormalizeBlockedCompanies normalizes and deduplicates a blocklist, dropping empty entries.
+func NormalizeBlockedCompanies(entries []string) []string {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, entry := range entries {
+		key := NormalizeBlockedCompany(entry)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, key)
+	}
+
+	return normalized
+}
+
+// EmailDomain returns the lowercase domain of an email address.
+func EmailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at == -1 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[at+1:]))
+}
+
+// employerKeys are the blocklist entries that match the viewer: their company name, their email
+// domain and every parent domain of it, so that blocking "acme.com" also covers "eu.acme.com".
+func (v *Viewer) employerKeys() []string {
+	keys := []string{}
+	if v.Company != "" {
+		if key := NormalizeBlockedCompany(v.Company); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	labels := strings.Split(v.EmailDomain, ".")
+	for i := 0; i < len(labels)-1; i++ {
+		keys = append(keys, strings.Join(labels[i:], "."))
+	}
+
+	return keys
+}
+
+// IsBlockedBy tells whether the engineer blocked the viewer's company or email domain.
+func (v *Viewer) IsBlockedBy(e *Engineer) bool {
+	if len(e.BlockedCompanies) == 0 {
+		return false
+	}
+
+	for _, key := range v.employerKeys() {
+		for _, blocked := range e.BlockedCompanies {
+			if blocked == key {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// NormalizeBlockedCompanies normalizes the blocklist of the update, if it changes it.
+func (u *UpdateEngineerPayload) NormalizeBlockedCompanies() {
+	if u.BlockedCompanies == nil {
+		return
+	}
+
+	normalized := NormalizeBlockedCompanies(*u.BlockedCompanies)
+	u.BlockedCompanies = &normalized
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// minBioLength is how long a bio has to be to count: a line or two says little more than the
+// tagline already does.
+const minBioLength = 200
+
+// CompletenessNudgeSettings tells who gets reminded to complete their profile: users scoring below
+// Threshold whose profile hasn't changed for After, at most MaxNudges times, After apart.
+type CompletenessNudgeSettings struct {
+	Threshold int
+	After time.Duration
+	MaxNudges int
+}
+
+// CompletenessNudgeSettingsFromEnv reads COMPLETENESS_NUDGE_THRESHOLD, COMPLETENESS_NUDGE_AFTER_DAYS
+// and COMPLETENESS_NUDGE_MAX, falling back to the defaults when unset or invalid.
+func CompletenessNudgeSettingsFromEnv() CompletenessNudgeSettings {
+	return CompletenessNudgeSettings{
+		Threshold: envInt("COMPLETENESS_NUDGE_THRESHOLD", 60),
+		After: time.Duration(envInt("COMPLETENESS_NUDGE_AFTER_DAYS", 7)) * 24 * time.Hour,
+		MaxNudges: envInt("COMPLETENESS_NUDGE_MAX", 3),
+	}
+}
+
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// Completeness is how far a profile is filled in. Score is the sum of the weights of the items
+// done, out of 100, and Missing the items left, the most important first.
+type Completeness struct {
+	Score int
+	Missing []CompletenessHint
+}
+
+type CompletenessHint struct {
+	Item string
+	Weight int
+	Hint string
+}
+
+type engineerChecklistItem struct {
+	CompletenessHint
+	done func(e *Engineer) bool
+}
+
+type recruiterChecklistItem struct {
+	CompletenessHint
+	done func(r *Recruiter) bool
+}
+
+// The weights of each checklist add up to 100. Optional links are worth little, as plenty of
+// good profiles have none of them, but they still count.
+var engineerChecklist = []engineerChecklistItem{
+	{CompletenessHint{"experience", 15, "Add your work experience"}, func(e *Engineer) bool { return len(e.Experience) > 0 }},
+	{CompletenessHint{"bio", 12, "Write a bio of at least 200 characters about what you build and how you work"}, func(e *Engineer) bool { return utf8.RuneCountInString(strings.TrimSpace(e.Bio)) >= minBioLength }},
+	{CompletenessHint{"avatar", 10, "Add a profile picture"}, func(e *Engineer) bool { return e.Avatar != "" }},
+	{CompletenessHint{"projects", 10, "Showcase a project you are proud of"}, func(e *Engineer) bool { return len(e.Projects) > 0 }},
+	{CompletenessHint{"tagline", 8, "Sum yourself up in a tagline"}, func(e *Engineer) bool { return strings.TrimSpace(e.Tagline) != "" }},
+	{CompletenessHint{"preferences", 8, "Tell recruiters what roles you are looking for"}, func(e *Engineer) bool { return e.SearchStatus != "" && len(e.RoleType) > 0 && len(e.RoleLevel) > 0 }},
+	{CompletenessHint{"location", 6, "Add the city and country you work from"}, func(e *Engineer) bool { return e.City != "" && e.Country != "" }},
+	{CompletenessHint{"education", 6, "Add your education"}, func(e *Engineer) bool { return len(e.Education) > 0 }},
+	{CompletenessHint{"github", 6, "Link your GitHub profile"}, func(e *Engineer) bool { return e.Github != "" }},
+	{CompletenessHint{"linkedin", 6, "Link your LinkedIn profile"}, func(e *Engineer) bool { return e.LinkedIn != "" }},
+	{CompletenessHint{"timezone", 4, "Set your timezone so recruiters can plan calls"}, func(e *Engineer) bool { return e.Timezone != "" }},
+	{CompletenessHint{"website", 4, "Link your website or blog"}, func(e *Engineer) bool { return e.Website != "" }},
+	{CompletenessHint{"stackoverflow", 3, "Link your Stack Overflow profile"}, func(e *Engineer) bool { return e.StackOverflow != "" }},
+	{CompletenessHint{"twitter", 2, "Link your Twitter profile"}, func(e *Engineer) bool { return e.Twitter != "" }},
+}
+
+var recruiterChecklist = []recruiterChecklistItem{
+	{CompletenessHint{"bio", 20, "Describe your company and the teams you hire for"}, func(r *Recruiter) bool { return strings.TrimSpace(r.Bio) != "" }},
+	{CompletenessHint{"logo", 20, "Add your company logo"}, func(r *Recruiter) bool { return r.Logo != "" }},
+	{CompletenessHint{"company", 15, "Add your company name"}, func(r *Recruiter) bool { return strings.TrimSpace(r.Company) != "" }},
+	{CompletenessHint{"linkedin", 15, "Link your LinkedIn profile"}, func(r *Recruiter) bool { return r.LinkedIn != "" }},
+	{CompletenessHint{"name", 10, "Add your first and last name"}, func(r *Recruiter) bool { return r.Firstname != "" && r.Lastname != "" }},
+	{CompletenessHint{"role", 10, "Add your role at the company"}, func(r *Recruiter) bool { return strings.TrimSpace(r.Role) != "" }},
+	{CompletenessHint{"website", 10, "Link your company website"}, func(r *Recruiter) bool { return r.Website != "" }},
+}
+
+func (e *Engineer) Completeness() Completeness {
+	completeness := Completeness{Missing: []CompletenessHint{}}
+	for _, item := range engineerChecklist {
+		if item.done(e) {
+			completeness.Score += item.Weight
+		} else {
+			completeness.Missing = append(completeness.Missing, item.CompletenessHint)
+		}
+	}
+	return completeness
+}
+
+func (r *Recruiter) Completeness() Completeness {
+	completeness := Completeness{Missing: []CompletenessHint{}}
+	for _, item := range recruiterChecklist {
+		if item.done(r) {
+			completeness.Score += item.Weight
+		} else {
+			completeness.Missing = append(completeness.Missing, item.CompletenessHint)
+		}
+	}
+	return completeness
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ContactStatusPending  = "pending"
+	ContactStatusAccepted = "accepted"
+	ContactStatusDeclined = "declined"
+)
+
+// ContactRequestCooldown is how long a recruiter waits after a decline before asking the same
+// engineer again.
+const ContactRequestCooldown = 90 * 24 * time.Hour
+
+// ContactRequest is a recruiter reaching out to an engineer about a role. Once accepted, the
+// recruiter is a contact of the engineer and sees the fields restricted to contacts.
+type ContactRequest struct {
+	ID uuid.UUID				`bson:"_id,required"`
+	RecruiterID uuid.UUID		`bson:"recruiter_id,required"`
+	RecruiterUserID uuid.UUID	`bson:"recruiter_user_id,required"`
+	EngineerID uuid.UUID		`bson:"engineer_id,required"`
+	EngineerUserID uuid.UUID	`bson:"engineer_user_id,required"`
+	RoleTitle string			`bson:"role_title,required"`
+	RoleDescription string		`bson:"role_description,required"`
+	RoleType string				`bson:"role_type,omitempty"`
+	RoleLevel string			`bson:"role_level,omitempty"`
+	Message string				`bson:"message,omitempty"`
+	Status string				`bson:"status,required"`
+	EngineerEmail string		`bson:"engineer_email,omitempty"`
+	CreatedAt time.Time			`bson:"created_at,required"`
+	RespondedAt *time.Time		`bson:"responded_at,omitempty"`
+}
+
+type CreateContactRequestPayload struct {
+	RoleTitle string		`json:"roleTitle" validate:"required,max=150"`
+	RoleDescription string	`json:"roleDescription" validate:"required,max=5000"`
+	RoleType string			`json:"roleType,omitempty" validate:"omitempty,oneof=contract_part_time contract_full_time employee_part_time employee_full_time"`
+	RoleLevel string		`json:"roleLevel,omitempty" validate:"omitempty,oneof=junior mid_level senior principal_staff c_level"`
+	Message string			`json:"message,omitempty" validate:"omitempty,max=2000"`
+}
+
+type RespondContactRequestPayload struct {
+	Status string			`json:"status" validate:"required,oneof=accepted declined"`
+}
+
+func (p *CreateContactRequestPayload) NewContactRequest(recruiter *Recruiter, engineer *Engineer) *ContactRequest {
+	return &ContactRequest{
+		ID: uuid.New(),
+		RecruiterID: recruiter.ID,
+		RecruiterUserID: recruiter.UserID,
+		EngineerID: engineer.ID,
+		EngineerUserID: engineer.UserID,
+		RoleTitle: p.RoleTitle,
+		RoleDescription: p.RoleDescription,
+		RoleType: p.RoleType,
+		RoleLevel: p.RoleLevel,
+		Message: p.Message,
+		Status: ContactStatusPending,
+		CreatedAt: time.Now(),
+	}
+}
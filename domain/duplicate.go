@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateEngineers is a set of engineer profiles sharing the value of Field, which suggests
+// they belong to the same person.
+type DuplicateEngineers struct {
+	Field string
+	Value string
+	Engineers []*Engineer
+}
+
+// duplicateKeys are what is compared between profiles, by field. Names alone are common enough
+// that they only count along with the country.
+var duplicateKeys = []struct {
+	field string
+	key func(e *Engineer) string
+}{
+	{"Github", func(e *Engineer) string { return strings.ToLower(GithubLogin(e.Github)) }},
+	{"LinkedIn", func(e *Engineer) string { return linkedinHandle(e.LinkedIn) }},
+	{"Name", func(e *Engineer) string {
+		name := normalizePlace(e.Firstname + " " + e.Lastname)
+		country := normalizePlace(e.Country)
+		if name == "" || country == "" {
+			return ""
+		}
+		return name + ", " + country
+	}},
+}
+
+// FindDuplicateEngineers groups the engineers sharing a GitHub account, a LinkedIn profile, or a
+// name in the same country.
+func FindDuplicateEngineers(engineers []*Engineer) []DuplicateEngineers {
+	duplicates := []DuplicateEngineers{}
+
+	for _, duplicateKey := range duplicateKeys {
+		groups := map[string][]*Engineer{}
+		for _, engineer := range engineers {
+			if key := duplicateKey.key(engineer); key != "" {
+				groups[key] = append(groups[key], engineer)
+			}
+		}
+
+		keys := []string{}
+		for key, group := range groups {
+			if len(group) > 1 {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			duplicates = append(duplicates, DuplicateEngineers{Field: duplicateKey.field, Value: key, Engineers: groups[key]})
+		}
+	}
+
+	return duplicates
+}
+
+// linkedinHandle returns the lowercase handle of a linkedin.com/in/ profile URL, or "" for any
+// other URL.
+func linkedinHandle(profileURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(profileURL))
+	if err != nil {
+		return ""
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return ""
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "in" || parts[1] == "" {
+		return ""
+	}
+
+	handle, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(handle)
+}
+
+// Flags flags every profile of the set, each naming the other ones in Details so that the admin
+// reviewing it can compare them.
+func (d *DuplicateEngineers) Flags(now time.Time) []*ModerationFlag {
+	flags := []*ModerationFlag{}
+	for _, engineer := range d.Engineers {
+		others := []string{}
+		for _, other := range d.Engineers {
+			if other.ID != engineer.ID {
+				others = append(others, other.ID.String())
+			}
+		}
+		sort.Strings(others)
+
+		flags = append(flags, &ModerationFlag{
+			ID: uuid.New(),
+			ProfileType: ProfileTypeEngineer,
+			ProfileID: engineer.ID,
+			UserID: engineer.UserID,
+			Source: ModerationSourceDuplicate,
+			Field: d.Field,
+			Reason: ModerationReasonDuplicate,
+			Details: d.Value + " is shared with engineers " + strings.Join(others, ", "),
+			Status: ModerationFlagOpen,
+			CreatedAt: now,
+		})
+	}
+	return flags
+}
@@ -1,8 +1,8 @@
-
 package domain
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,6 +13,31 @@ import (
 
 func SendNewEmail(templateId, userId, receiverEmail string, code int) error {
 	internal.LogInfo("Starting sending sign up confirmation email", map[string]interface{}{"user_id": userId })
+
+	err := SendTemplateEmail(templateId, receiverEmail, map[string]interface{}{
+		"user_id": userId,
+		"verification_code": fmt.Sprint(code),
+	})
+	if err != nil {
+		return err
+	}
+
+	internal.LogInfo("Successfully sent sign up confirmation email", map[string]interface{}{"user_id": userId })
+	return nil
+}
+
+type EmailAttachment struct {
+	Filename string
+	ContentType string
+	Content []byte
+}
+
+// SendTemplateEmail sends a mailtrap template to a single receiver.
+func SendTemplateEmail(templateId, receiverEmail string, variables map[string]interface{}) error {
+	return SendTemplateEmailWithAttachments(templateId, receiverEmail, variables, nil)
+}
+
+func SendTemplateEmailWithAttachments(templateId, receiverEmail string, variables map[string]interface{}, attachments []EmailAttachment) error {
 	mailTrapToken := os.Getenv("MAILTRAP_TOKEN")
 		requestBody := map[string]interface{}{
 		"from": map[string]string{
@@ -23,10 +48,20 @@ func SendNewEmail(templateId, userId, receiverEmail string, code int) error {
 			map[string]string{"email": receiverEmail},
 		},
 		"template_uuid": templateId,
-		"template_variables": map[string]string{
-			"user_id": userId,
-			"verification_code": fmt.Sprint(code),
-		},
+		"template_variables": variables,
+	}
+
+	if len(attachments) > 0 {
+		encoded := make([]map[string]string, 0, len(attachments))
+		for _, attachment := range attachments {
+			encoded = append(encoded, map[string]string{
+				"filename": attachment.Filename,
+				"type": attachment.ContentType,
+				"disposition": "attachment",
+				"content": base64.StdEncoding.EncodeToString(attachment.Content),
+			})
+		}
+		requestBody["attachments"] = encoded
 	}
 
 	client := &http.Client {}
@@ -57,6 +92,14 @@ func SendNewEmail(templateId, userId, receiverEmail string, code int) error {
 		return err
 	}
 
-	internal.LogInfo("Successfully sent sign up confirmation email", map[string]interface{}{"user_id": userId })
 	return nil
 }
+
+// FrontendURL is the base of links put in emails.
+func FrontendURL() string {
+	url := os.Getenv("FRONTEND_URL")
+	if url == "" {
+		return "https://angulartalents.com"
+	}
+	return url
+}
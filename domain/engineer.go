@@ -4,9 +4,12 @@ import (
 	"context"
 	"crypto/md5"
 	"errors"
+	"fmt"
 	"net/url"
 	"angular-talents-backend/db"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -21,32 +24,55 @@ type PartialEngineer struct {
 	City string				`bson:"city,required"`
 	State string			`bson:"state,omitempty"`
 	Country string			`bson:"country,required"`
-	Avatar string			`bson: "avatar,required"`
+	CountryCode string		`bson:"country_code,omitempty"`
+	Timezone string			`bson:"timezone,omitempty"`
+	Avatar string			`bson:"avatar,required"`
 	Bio string				`bson:"bio,required"`
 	SearchStatus string		`bson:"search_status,required"`
 	RoleType []string		`bson:"role_type,required"`
 	RoleLevel []string		`bson:"role_level,required"`
+	Slug string				`bson:"slug,omitempty"`
 }
 
 type Engineer struct {
 	ID uuid.UUID 			`bson:"_id,required"`
 	UserID uuid.UUID		`bson:"user_id,required"`
+	Slug string				`bson:"slug,omitempty"`
 	Firstname string 		`bson:"first_name,required"`
 	Lastname string			`bson:"last_name,required"`
 	Tagline string			`bson:"tagline,required"`
 	City string				`bson:"city,required"`
 	State string			`bson:"state,omitempty"`
 	Country string			`bson:"country,required"`
-	Avatar string			`bson: "avatar,required"`
+	CountryCode string		`bson:"country_code,omitempty"`
+	Timezone string			`bson:"timezone,omitempty"`
+	TimezoneGeocoded bool	`bson:"timezone_geocoded,omitempty" json:"-"`
+	Location *GeoPoint		`bson:"location,omitempty"`
+	Avatar string			`bson:"avatar,required"`
+	AvatarImage *UploadedImage	`bson:"avatar_image,omitempty"`
 	Bio string				`bson:"bio,required"`
 	SearchStatus string		`bson:"search_status,required"`
 	RoleType []string		`bson:"role_type,required"`
 	RoleLevel []string		`bson:"role_level,required"`
 	Website string			`bson:"website,omitempty"`
 	Github string			`bson:"github,required"`
+	GithubStats *GithubStats	`bson:"github_stats,omitempty"`
+	GithubCheckedAt time.Time	`bson:"github_checked_at,omitempty" json:"-"`
 	Twitter string			`bson:"twitter,omitempty"`
 	LinkedIn string			`bson:"linkedin,required"`
 	StackOverflow string	`bson:"stackoverflow,omitempty"`
+	FieldVisibility map[string]string	`bson:"field_visibility,omitempty"`
+	BlockedCompanies []string	`bson:"blocked_companies,omitempty"`
+	Hidden *ProfileHide		`bson:"hidden,omitempty"`
+	Experience []ExperienceEntry	`bson:"experience,omitempty"`
+	Education []EducationEntry		`bson:"education,omitempty"`
+	Projects []PortfolioProject		`bson:"projects,omitempty"`
+	Version int				`bson:"version,omitempty"`
+	CompletenessScore int	`bson:"completeness_score"`
+	CompletenessNudgedAt time.Time	`bson:"completeness_nudged_at,omitempty" json:"-"`
+	CompletenessNudges int	`bson:"completeness_nudges,omitempty" json:"-"`
+	CreatedAt time.Time		`bson:"created_at,omitempty"`
+	UpdatedAt time.Time		`bson:"updated_at,omitempty"`
 }
 
 type CreateEngineerPayload struct {
@@ -56,7 +82,8 @@ type CreateEngineerPayload struct {
 	City string			`json:"city"  validate:"required"`
 	State string		`json:"state,omitempty"`
 	Country string		`json:"country"  validate:"required"`
-	Avatar string			`json: "avatar"  validate:"required"`
+	Timezone string		`json:"timezone,omitempty"  validate:"omitempty,timezone"`
+	Avatar string		`json:"avatar,omitempty"  validate:"omitempty,url"`
 	Bio string			`json:"bio"  validate:"required"`
 	SearchStatus string	`json:"searchStatus"  validate:"required,oneof=actively_looking open not_interested invisible"`
 	RoleType []string	`json:"roleType"  validate:"required,dive,oneof=contract_part_time contract_full_time employee_part_time employee_full_time"`
@@ -66,6 +93,8 @@ type CreateEngineerPayload struct {
 	Twitter string		`json:"twitter,omitempty"  validate:"omitempty,url"`
 	LinkedIn string		`json:"linkedIn"  validate:"required,url"`
 	StackOverflow string`json:"stackOverflow,omitempty"  validate:"omitempty,url"`
+	FieldVisibility map[string]string	`json:"fieldVisibility,omitempty"`
+	BlockedCompanies []string	`json:"blockedCompanies,omitempty" validate:"omitempty,max=50,dive,required,max=100"`
 }
 
 type UpdateEngineerPayload struct {
@@ -75,20 +104,35 @@ type UpdateEngineerPayload struct {
 	City string			`bson:"city,omitempty" json:"city"  validate:"omitempty,alpha"`
 	State string		`bson:"state,omitempty" json:"state,omitempty" validate:"omitempty"`
 	Country string		`bson:"country,omitempty" json:"country"  validate:"omitempty,alpha"`
-	Avatar string			`bson: "avatar,omitempty" json:"avatar"  validate:"omitempty,alpha"`
+	Timezone string		`bson:"timezone,omitempty" json:"timezone,omitempty"  validate:"omitempty,timezone"`
+	TimezoneGeocoded bool	`bson:"timezone_geocoded,omitempty" json:"-"`
+	CountryCode string	`bson:"country_code,omitempty" json:"-"`
+	Location *GeoPoint	`bson:"location,omitempty" json:"-"`
+	Avatar string		`bson:"avatar,omitempty" json:"avatar"  validate:"omitempty,url"`
 	Bio string			`bson:"bio,omitempty" json:"bio" validate:"omitempty"`
 	SearchStatus string	`bson:"search_status,omitempty" json:"searchStatus"  validate:"omitempty,oneof=actively_looking open not_interested invisible"`
 	RoleType []string	`bson:"role_type,omitempty" json:"roleType"  validate:"omitempty,dive,oneof=contract_part_time contract_full_time employee_part_time employee_full_time"`
 	RoleLevel []string	`bson:"role_level,omitempty" json:"roleLevel"  validate:"omitempty,dive,oneof=junior mid_level senior principal_staff c_level"`
 	Website string		`bson:"website,omitempty" json:"website,omitempty"  validate:"omitempty,url"`
+	Github string		`bson:"github,omitempty" json:"github,omitempty"  validate:"omitempty,url"`
 	Twitter string		`bson:"twitter,omitempty" json:"twitter,omitempty"  validate:"omitempty,url"`
 	StackOverflow string`bson:"stackoverflow,omitempty" json:"stackOverflow,omitempty"  validate:"omitempty,url"`
+	FieldVisibility map[string]string	`bson:"field_visibility,omitempty" json:"fieldVisibility,omitempty"`
+	BlockedCompanies *[]string	`bson:"blocked_companies,omitempty" json:"blockedCompanies,omitempty" validate:"omitempty,max=50,dive,required,max=100"`
+	Experience []ExperienceEntry	`bson:"experience,omitempty" json:"-"`
+	Education []EducationEntry		`bson:"education,omitempty" json:"-"`
+	Projects []PortfolioProject		`bson:"projects,omitempty" json:"-"`
+	UpdatedAt time.Time	`bson:"updated_at,omitempty" json:"-"`
+	ClearedFields []string	`bson:"-" json:"-"`
 }
 
 type ReadEngineerPayload struct {
 	EngineerID string 	`json:"engineerId" validate:"required,min=36"`
 }
 
+// TeaserPageSize is the number of engineers shown to anonymous visitors, who can't paginate.
+const TeaserPageSize int64 = 3
+
 type ListEngineersPagination struct {
 	Page int64 		`json:"page" bson:"page"`
 	Limit int64 	`json:"limit" bson:"limit"`
@@ -96,14 +140,29 @@ type ListEngineersPagination struct {
 
 type ListEngineersFilter struct {
 	Country string 		`json:"country"  bson:"country,omitempty"`
-	SearchStatus string `json:"searchStatus" bson:"searchStatus,omitempty"`
-	RoleLevel string 	`json:"roleLevel" bson:"roleLevel,omitempty"`
-	RoleType string 	`json:"roleType" bson:"roleType,omitempty"`
+	SearchStatus string `json:"searchStatus" bson:"search_status,omitempty"`
+	RoleLevel string 	`json:"roleLevel" bson:"role_level,omitempty"`
+	RoleType string 	`json:"roleType" bson:"role_type,omitempty"`
+	Near *GeoPoint 		`json:"near,omitempty" bson:"near,omitempty"`
+	RadiusKm float64 	`json:"radius,omitempty" bson:"radius_km,omitempty"`
+	Timezone string 	`json:"timezone,omitempty" bson:"timezone,omitempty"`
+	IncludeNotInterested bool `json:"includeNotInterested,omitempty" bson:"include_not_interested,omitempty"`
 }
 
+// EngineerSortCompleteness lists the most complete profiles first.
+const EngineerSortCompleteness = "completeness"
+
+// EngineerSortChanged lists the least recently updated profiles first, for readers catching up
+// with changes in batches.
+const EngineerSortChanged = "changed"
+
 type ListEngineersParams struct {
 	Pagination *ListEngineersPagination
 	Filter *ListEngineersFilter
+	Viewer *Viewer
+	ChangedSince *time.Time
+	ExcludeIDs []uuid.UUID
+	Sort string
 }
 
 func (e *Engineer) NewPartialEngineer() (*PartialEngineer) {
@@ -114,11 +173,14 @@ func (e *Engineer) NewPartialEngineer() (*PartialEngineer) {
 		City: e.City,
 		State: e.State,
 		Country: e.Country,
+		CountryCode: e.CountryCode,
+		Timezone: e.Timezone,
 		Avatar: e.Avatar,
 		Bio: e.Bio,
 		SearchStatus: e.SearchStatus,
 		RoleType: e.RoleType,
 		RoleLevel: e.RoleLevel,
+		Slug: e.Slug,
 	}
 }
 
@@ -130,6 +192,8 @@ func (p *CreateEngineerPayload) NewEngineer(ctx context.Context) (*Engineer, err
 		return nil, err
 	}
 
+	now := time.Now()
+
 	 return &Engineer{
 		ID: engineerID,
 		UserID: userID,
@@ -139,6 +203,7 @@ func (p *CreateEngineerPayload) NewEngineer(ctx context.Context) (*Engineer, err
 		City: p.City,
 		State: p.State,
 		Country: p.Country,
+		Timezone: p.Timezone,
 		Avatar: p.Avatar,
 		Bio: p.Bio,
 		SearchStatus: p.SearchStatus,
@@ -149,16 +214,128 @@ func (p *CreateEngineerPayload) NewEngineer(ctx context.Context) (*Engineer, err
 		Twitter: p.Twitter,
 		LinkedIn: p.LinkedIn,
 		StackOverflow: p.StackOverflow,
+		FieldVisibility: p.FieldVisibility,
+		BlockedCompanies: NormalizeBlockedCompanies(p.BlockedCompanies),
+		CreatedAt: now,
+		UpdatedAt: now,
 	}, nil
 }
 
-func (u *UpdateEngineerPayload) Validate(ctx context.Context, userID uuid.UUID, engineerID string) error {
+// Geocode resolves the engineer's free-text location into coordinates, an ISO country code and,
+// unless the engineer picked one, a timezone. An unknown location is not an error: the profile
+// is simply left out of radius searches.
+func (e *Engineer) Geocode(ctx context.Context) error {
+	result, err := CurrentGeocoder().Geocode(ctx, GeocodeQuery{City: e.City, State: e.State, Country: e.Country})
+	if err != nil {
+		if err == ErrLocationNotFound {
+			return nil
+		}
+		return err
+	}
+
+	e.Location = result.Location
+	e.CountryCode = result.CountryCode
+	if e.Timezone == "" && result.Timezone != "" {
+		e.Timezone = result.Timezone
+		e.TimezoneGeocoded = true
+	}
+
+	return nil
+}
+
+// Geocode re-resolves the location when any of its parts is being updated, filling the parts
+// left untouched by the payload from the current engineer. The timezone follows the location
+// only while it was itself geocoded: once the engineer picks one, it is kept.
+func (u *UpdateEngineerPayload) Geocode(ctx context.Context, current *Engineer) error {
+	if u.Timezone != "" && current.TimezoneGeocoded {
+		u.ClearedFields = append(u.ClearedFields, "timezone_geocoded")
+	}
+
+	if u.City == "" && u.State == "" && u.Country == "" {
+		return nil
+	}
+
+	merged := &Engineer{City: current.City, State: current.State, Country: current.Country}
+	if u.City != "" {
+		merged.City = u.City
+	}
+	if u.State != "" {
+		merged.State = u.State
+	}
+	if u.Country != "" {
+		merged.Country = u.Country
+	}
+
+	// Whatever the new location doesn't resolve to is cleared rather than left pointing at the
+	// previous one, including when the geocoder fails.
+	err := merged.Geocode(ctx)
+	if merged.Location == nil {
+		u.ClearedFields = append(u.ClearedFields, "location")
+	}
+	if merged.CountryCode == "" {
+		u.ClearedFields = append(u.ClearedFields, "country_code")
+	}
+	followsLocation := u.Timezone == "" && (current.Timezone == "" || current.TimezoneGeocoded)
+	if followsLocation && merged.Location == nil && current.Timezone != "" {
+		u.ClearedFields = append(u.ClearedFields, "timezone", "timezone_geocoded")
+	}
+	if err != nil {
+		return err
+	}
+
+	u.Location = merged.Location
+	u.CountryCode = merged.CountryCode
+	if followsLocation && merged.Timezone != "" {
+		u.Timezone = merged.Timezone
+		u.TimezoneGeocoded = true
+	}
+
+	return nil
+}
+
+// ClearStaleGithubStats drops the cached GitHub stats when the payload links another account, so
+// that the previous account's stats aren't shown until the next refresh fetches the new one's.
+func (u *UpdateEngineerPayload) ClearStaleGithubStats(current *Engineer) {
+	if u.Github == "" || strings.EqualFold(GithubLogin(u.Github), GithubLogin(current.Github)) {
+		return
+	}
+	u.ClearedFields = append(u.ClearedFields, "github_stats", "github_checked_at")
+}
+
+// UpdateDocument builds the update applying the payload, unsetting the fields it cleared.
+func (u *UpdateEngineerPayload) UpdateDocument() bson.M {
+	update := bson.M{"$set": u}
+	if len(u.ClearedFields) == 0 {
+		return update
+	}
+
+	unset := bson.M{}
+	for _, field := range u.ClearedFields {
+		unset[field] = ""
+	}
+	update["$unset"] = unset
+
+	return update
+}
+
+// ValidateFields checks the payload itself, including the visibility settings, for updates that
+// don't need an ownership check.
+func (u *UpdateEngineerPayload) ValidateFields() error {
 	v := validator.New()
 	err := v.Struct(u)
 	if err != nil {
 		return err
 	}
 
+	return EngineerFieldPolicy.Validate(u.FieldVisibility)
+}
+
+func (u *UpdateEngineerPayload) Validate(ctx context.Context, userID uuid.UUID, engineerID string) error {
+	err := u.ValidateFields()
+	if err != nil {
+		return err
+	}
+
 	parsedEngineerID, err := uuid.Parse(engineerID)
 	if err != nil {
 		return err
@@ -197,16 +374,21 @@ func (e *UpdateEngineerPayload) checkUserOwnsEngineer(ctx context.Context, userI
 	return true, nil
 }
 
-func NewListEngineerParams(isMember bool, q url.Values) (*ListEngineersParams, error) {
+func NewListEngineerParams(ctx context.Context, isMember bool, q url.Values) (*ListEngineersParams, error) {
 	params := &ListEngineersParams{
 		Pagination: &ListEngineersPagination{
 			Page: 1,
 			Limit: 10,
 		},
 		Filter:  &ListEngineersFilter{},
+		Viewer: NewViewer(ctx),
 	}
 
-	if !isMember {
+	if !params.Viewer.IsAuthenticated() {
+		params.Pagination.Limit = TeaserPageSize
+	}
+
+	if !isMember && !params.Viewer.IsAdmin {
 		return params, nil
 	}
 
@@ -234,6 +416,14 @@ func NewListEngineerParams(isMember bool, q url.Values) (*ListEngineersParams, e
 		params.Filter.SearchStatus = q.Get("searchStatus")
 	}
 
+	if q.Get("includeNotInterested") != "" {
+		include, err := strconv.ParseBool(q.Get("includeNotInterested"))
+		if err != nil {
+			return nil, err
+		}
+		params.Filter.IncludeNotInterested = include
+	}
+
 	if q.Get("roleLevel") != "" {
 		params.Filter.RoleLevel = q.Get("roleLevel")
 	}
@@ -242,5 +432,46 @@ func NewListEngineerParams(isMember bool, q url.Values) (*ListEngineersParams, e
 		params.Filter.RoleType = q.Get("roleType")
 	}
 
+	if q.Get("near") != "" {
+		if !EngineerFieldPolicy.AllowsByDefault("Location", params.Viewer) {
+			return nil, errors.New("near requires access to engineers' locations")
+		}
+		near, err := ParseNear(ctx, q.Get("near"))
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve near location %q: %w", q.Get("near"), err)
+		}
+		params.Filter.Near = near
+		params.Filter.RadiusKm = DefaultNearRadiusKm
+	}
+
+	if q.Get("radius") != "" {
+		if params.Filter.Near == nil {
+			return nil, errors.New("radius requires a near location")
+		}
+		radius, err := strconv.ParseFloat(q.Get("radius"), 64)
+		if err != nil {
+			return nil, err
+		}
+		if radius <= 0 || radius > MaxNearRadiusKm {
+			return nil, fmt.Errorf("radius must be between 0 and %v km", MaxNearRadiusKm)
+		}
+		params.Filter.RadiusKm = radius
+	}
+
+	if q.Get("timezone") != "" {
+		_, err := ParseTimezoneFilter(q.Get("timezone"))
+		if err != nil {
+			return nil, err
+		}
+		params.Filter.Timezone = q.Get("timezone")
+	}
+
+	if q.Get("sort") != "" {
+		if q.Get("sort") != EngineerSortCompleteness {
+			return nil, fmt.Errorf("unknown sort %q", q.Get("sort"))
+		}
+		params.Sort = q.Get("sort")
+	}
+
 	return params, nil
 }
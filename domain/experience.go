@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const monthLayout = "2006-01"
+
+type ExperienceEntry struct {
+	ID uuid.UUID				`bson:"id,required"`
+	Company string				`bson:"company,required"`
+	Title string				`bson:"title,required"`
+	StartDate string			`bson:"start_date,required"`
+	EndDate string				`bson:"end_date,omitempty"`
+	Current bool				`bson:"current,omitempty"`
+	Description string			`bson:"description,omitempty"`
+	Technologies []string		`bson:"technologies,omitempty"`
+}
+
+type EducationEntry struct {
+	ID uuid.UUID				`bson:"id,required"`
+	School string				`bson:"school,required"`
+	Degree string				`bson:"degree,omitempty"`
+	FieldOfStudy string			`bson:"field_of_study,omitempty"`
+	StartDate string			`bson:"start_date,required"`
+	EndDate string				`bson:"end_date,omitempty"`
+	Description string			`bson:"description,omitempty"`
+}
+
+type PortfolioProject struct {
+	ID uuid.UUID				`bson:"id,required"`
+	Name string					`bson:"name,required"`
+	URL string					`bson:"url,omitempty"`
+	Repo string					`bson:"repo,omitempty"`
+	Description string			`bson:"description,omitempty"`
+	Screenshots []string		`bson:"screenshots,omitempty"`
+	Technologies []string		`bson:"technologies,omitempty"`
+}
+
+type ExperiencePayload struct {
+	Company string				`json:"company" validate:"required,max=100"`
+	Title string				`json:"title" validate:"required,max=100"`
+	StartDate string			`json:"startDate" validate:"required,datetime=2006-01"`
+	EndDate string				`json:"endDate,omitempty" validate:"omitempty,datetime=2006-01"`
+	Current bool				`json:"current,omitempty"`
+	Description string			`json:"description,omitempty" validate:"omitempty,max=5000"`
+	Technologies []string		`json:"technologies,omitempty" validate:"omitempty,max=30,dive,required,max=50"`
+}
+
+type EducationPayload struct {
+	School string				`json:"school" validate:"required,max=150"`
+	Degree string				`json:"degree,omitempty" validate:"omitempty,max=100"`
+	FieldOfStudy string			`json:"fieldOfStudy,omitempty" validate:"omitempty,max=100"`
+	StartDate string			`json:"startDate" validate:"required,datetime=2006-01"`
+	EndDate string				`json:"endDate,omitempty" validate:"omitempty,datetime=2006-01"`
+	Description string			`json:"description,omitempty" validate:"omitempty,max=5000"`
+}
+
+type PortfolioProjectPayload struct {
+	Name string					`json:"name" validate:"required,max=100"`
+	URL string					`json:"url,omitempty" validate:"omitempty,url"`
+	Repo string					`json:"repo,omitempty" validate:"omitempty,url"`
+	Description string			`json:"description,omitempty" validate:"omitempty,max=5000"`
+	Screenshots []string		`json:"screenshots,omitempty" validate:"omitempty,max=10,dive,url"`
+	Technologies []string		`json:"technologies,omitempty" validate:"omitempty,max=30,dive,required,max=50"`
+}
+
+// ProfileSectionPayload is the body of an entry added to, or replacing one of, a profile section.
+type ProfileSectionPayload interface {
+	Validate(now time.Time) error
+	Entry(id uuid.UUID) interface{}
+	ModerationContent() ProfileContent
+}
+
+// ProfileSection is a list of entries stored on the engineer document. Sections with a SortKey
+// are kept in reverse chronological order.
+type ProfileSection struct {
+	Field string
+	SortKey string
+	NewPayload func() ProfileSectionPayload
+}
+
+// ProfileSections are keyed by the name used in the /engineers/me/{section} routes.
+var ProfileSections = map[string]ProfileSection{
+	"experience": {Field: "experience", SortKey: "start_date", NewPayload: func() ProfileSectionPayload { return &ExperiencePayload{} }},
+	"education":  {Field: "education", SortKey: "start_date", NewPayload: func() ProfileSectionPayload { return &EducationPayload{} }},
+	"projects":   {Field: "projects", NewPayload: func() ProfileSectionPayload { return &PortfolioProjectPayload{} }},
+}
+
+func (p *ExperiencePayload) Validate(now time.Time) error {
+	v := validator.New()
+	err := v.Struct(p)
+	if err != nil {
+		return err
+	}
+
+	if p.Current && p.EndDate != "" {
+		return fmt.Errorf("a current position has no end date")
+	}
+
+	return validateDateRange(p.StartDate, p.EndDate, now)
+}
+
+func (p *ExperiencePayload) Entry(id uuid.UUID) interface{} {
+	return &ExperienceEntry{
+		ID: id,
+		Company: p.Company,
+		Title: p.Title,
+		StartDate: p.StartDate,
+		EndDate: p.EndDate,
+		Current: p.Current,
+		Description: p.Description,
+		Technologies: p.Technologies,
+	}
+}
+
+func (p *EducationPayload) Validate(now time.Time) error {
+	v := validator.New()
+	err := v.Struct(p)
+	if err != nil {
+		return err
+	}
+
+	return validateDateRange(p.StartDate, p.EndDate, now)
+}
+
+func (p *EducationPayload) Entry(id uuid.UUID) interface{} {
+	return &EducationEntry{
+		ID: id,
+		School: p.School,
+		Degree: p.Degree,
+		FieldOfStudy: p.FieldOfStudy,
+		StartDate: p.StartDate,
+		EndDate: p.EndDate,
+		Description: p.Description,
+	}
+}
+
+func (p *PortfolioProjectPayload) Validate(now time.Time) error {
+	v := validator.New()
+	return v.Struct(p)
+}
+
+func (p *PortfolioProjectPayload) Entry(id uuid.UUID) interface{} {
+	return &PortfolioProject{
+		ID: id,
+		Name: p.Name,
+		URL: p.URL,
+		Repo: p.Repo,
+		Description: p.Description,
+		Screenshots: p.Screenshots,
+		Technologies: p.Technologies,
+	}
+}
+
+// validateDateRange checks that a range of months starts no later than the current month and
+// does not end before it starts.
+func validateDateRange(startDate, endDate string, now time.Time) error {
+	start, err := time.Parse(monthLayout, startDate)
+	if err != nil {
+		return err
+	}
+
+	if start.After(now) {
+		return fmt.Errorf("start date %s is in the future", startDate)
+	}
+
+	if endDate == "" {
+		return nil
+	}
+
+	end, err := time.Parse(monthLayout, endDate)
+	if err != nil {
+		return err
+	}
+
+	if end.Before(start) {
+		return fmt.Errorf("end date %s is before start date %s", endDate, startDate)
+	}
+
+	return nil
+}
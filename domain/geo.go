@@ -0,0 +1,237 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const earthRadiusKm = 6371.0
+
+const (
+	DefaultNearRadiusKm = 50.0
+	MaxNearRadiusKm     = 20000.0
+)
+
+var ErrLocationNotFound = errors.New("location not found")
+
+// GeoPoint is a GeoJSON point, stored as-is so that mongo can build a 2dsphere index on it.
+// Coordinates are in [longitude, latitude] order as mandated by GeoJSON.
+type GeoPoint struct {
+	Type        string    `bson:"type" json:"type"`
+	Coordinates []float64 `bson:"coordinates" json:"coordinates"`
+}
+
+type GeocodeQuery struct {
+	City    string
+	State   string
+	Country string
+}
+
+type GeocodeResult struct {
+	Location    *GeoPoint
+	CountryCode string
+	Timezone    string
+}
+
+// Geocoder resolves free-text locations. Implementations return a result with a nil Location
+// when only the country could be resolved, and ErrLocationNotFound when nothing matched.
+type Geocoder interface {
+	Geocode(ctx context.Context, query GeocodeQuery) (*GeocodeResult, error)
+}
+
+var geocoder Geocoder
+
+func SetGeocoder(g Geocoder) {
+	geocoder = g
+}
+
+func CurrentGeocoder() Geocoder {
+	if geocoder == nil {
+		geocoder = NewGeocoderFromEnv()
+	}
+	return geocoder
+}
+
+// NewGeocoderFromEnv picks the geocoder configured by GEOCODER. The bundled city dataset is the
+// default, so the service works offline; "nominatim" queries OpenStreetMap instead.
+func NewGeocoderFromEnv() Geocoder {
+	dataset := DefaultCityDataset()
+
+	switch os.Getenv("GEOCODER") {
+	case "nominatim":
+		return NewNominatimGeocoder(os.Getenv("NOMINATIM_URL"), dataset)
+	default:
+		return dataset
+	}
+}
+
+func NewGeoPoint(latitude, longitude float64) *GeoPoint {
+	return &GeoPoint{
+		Type:        "Point",
+		Coordinates: []float64{longitude, latitude},
+	}
+}
+
+func (p *GeoPoint) Latitude() float64 {
+	return p.Coordinates[1]
+}
+
+func (p *GeoPoint) Longitude() float64 {
+	return p.Coordinates[0]
+}
+
+// DistanceKm returns the great-circle distance between two points.
+func (p *GeoPoint) DistanceKm(other *GeoPoint) float64 {
+	lat1 := p.Latitude() * math.Pi / 180
+	lat2 := other.Latitude() * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (other.Longitude() - p.Longitude()) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// ParseNear resolves the `near` query parameter, which is either "lat,lng" or a place name
+// such as "Berlin" or "Austin, TX, United States".
+func ParseNear(ctx context.Context, near string) (*GeoPoint, error) {
+	parts := strings.Split(near, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	if len(parts) == 2 {
+		lat, latErr := strconv.ParseFloat(parts[0], 64)
+		lng, lngErr := strconv.ParseFloat(parts[1], 64)
+		if latErr == nil && lngErr == nil {
+			if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+				return nil, fmt.Errorf("near coordinates out of range: %s", near)
+			}
+			return NewGeoPoint(lat, lng), nil
+		}
+	}
+
+	query := GeocodeQuery{City: parts[0]}
+	switch len(parts) {
+	case 1:
+	case 2:
+		query.Country = parts[1]
+	default:
+		query.State = parts[1]
+		query.Country = parts[len(parts)-1]
+	}
+
+	result, err := CurrentGeocoder().Geocode(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Location == nil {
+		return nil, ErrLocationNotFound
+	}
+
+	return result.Location, nil
+}
+
+// TimezoneFilter matches either a single IANA zone or a range of UTC offsets in minutes.
+type TimezoneFilter struct {
+	Zone      string
+	MinOffset int
+	MaxOffset int
+}
+
+// ParseTimezoneFilter accepts an IANA name ("Europe/Berlin"), a fixed offset ("UTC+2",
+// "UTC-05:30") or a symmetric range around UTC ("UTC±2").
+func ParseTimezoneFilter(value string) (*TimezoneFilter, error) {
+	value = strings.TrimSpace(value)
+	upper := strings.ToUpper(value)
+
+	if !strings.HasPrefix(upper, "UTC") && !strings.HasPrefix(upper, "GMT") {
+		if _, err := time.LoadLocation(value); err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", value)
+		}
+		return &TimezoneFilter{Zone: value}, nil
+	}
+
+	rest := strings.TrimSpace(value[3:])
+	if rest == "" {
+		return &TimezoneFilter{MinOffset: 0, MaxOffset: 0}, nil
+	}
+
+	symmetric := false
+	sign := 1
+	switch {
+	case strings.HasPrefix(rest, "±"):
+		symmetric = true
+		rest = strings.TrimPrefix(rest, "±")
+	case strings.HasPrefix(rest, "+-"):
+		symmetric = true
+		rest = rest[2:]
+	case strings.HasPrefix(rest, "+"):
+		rest = rest[1:]
+	case strings.HasPrefix(rest, "-"):
+		sign = -1
+		rest = rest[1:]
+	default:
+		// an unescaped "+" in a query string is decoded as a space, which TrimSpace already removed
+	}
+
+	minutes, err := parseOffsetMinutes(rest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone offset %q", value)
+	}
+
+	if symmetric {
+		return &TimezoneFilter{MinOffset: -minutes, MaxOffset: minutes}, nil
+	}
+
+	return &TimezoneFilter{MinOffset: sign * minutes, MaxOffset: sign * minutes}, nil
+}
+
+func parseOffsetMinutes(value string) (int, error) {
+	hours, minutes := value, "0"
+	if i := strings.Index(value, ":"); i != -1 {
+		hours, minutes = value[:i], value[i+1:]
+	}
+
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 14 {
+		return 0, errors.New("invalid hours")
+	}
+
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 {
+		return 0, errors.New("invalid minutes")
+	}
+
+	return h*60 + m, nil
+}
+
+// MatchingZones narrows a list of IANA zones down to the ones the filter accepts at the given time,
+// so that offset ranges follow daylight saving transitions.
+func (f *TimezoneFilter) MatchingZones(zones []string, at time.Time) []string {
+	if f.Zone != "" {
+		return []string{f.Zone}
+	}
+
+	matching := []string{}
+	for _, zone := range zones {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			continue
+		}
+
+		_, offset := at.In(loc).Zone()
+		offsetMinutes := offset / 60
+		if offsetMinutes >= f.MinOffset && offsetMinutes <= f.MaxOffset {
+			matching = append(matching, zone)
+		}
+	}
+
+	return matching
+}
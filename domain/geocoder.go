@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed data/cities.csv
+var citiesCSV string
+
+//go:embed data/countries.csv
+var countriesCSV string
+
+var (
+	defaultDataset     *CityDatasetGeocoder
+	defaultDatasetOnce sync.Once
+)
+
+type datasetCity struct {
+	Name        string
+	State       string
+	CountryCode string
+	Location    *GeoPoint
+	Timezone    string
+}
+
+// CityDatasetGeocoder resolves locations against the city list bundled with the binary.
+// It only knows larger cities, but needs no network access and gives stable results.
+type CityDatasetGeocoder struct {
+	cities    map[string][]*datasetCity
+	all       []*datasetCity
+	countries map[string]string
+	names     map[string]string
+}
+
+func NewCityDatasetGeocoder() *CityDatasetGeocoder {
+	g := &CityDatasetGeocoder{
+		cities:    map[string][]*datasetCity{},
+		countries: map[string]string{},
+		names:     map[string]string{},
+	}
+
+	countryRows, err := csv.NewReader(strings.NewReader(countriesCSV)).ReadAll()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, row := range countryRows[1:] {
+		code := row[0]
+		g.countries[normalizePlace(code)] = code
+		g.countries[normalizePlace(row[1])] = code
+		g.names[code] = row[1]
+		for _, alias := range strings.Split(row[2], "|") {
+			if alias != "" {
+				g.countries[normalizePlace(alias)] = code
+			}
+		}
+	}
+
+	cityRows, err := csv.NewReader(strings.NewReader(citiesCSV)).ReadAll()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, row := range cityRows[1:] {
+		lat, err := strconv.ParseFloat(row[3], 64)
+		if err != nil {
+			panic(err)
+		}
+		lng, err := strconv.ParseFloat(row[4], 64)
+		if err != nil {
+			panic(err)
+		}
+
+		city := &datasetCity{
+			Name:        row[0],
+			State:       row[1],
+			CountryCode: row[2],
+			Location:    NewGeoPoint(lat, lng),
+			Timezone:    row[5],
+		}
+
+		key := normalizePlace(city.Name)
+		g.cities[key] = append(g.cities[key], city)
+		g.all = append(g.all, city)
+	}
+
+	return g
+}
+
+// DefaultCityDataset returns the bundled dataset, parsed once and shared.
+func DefaultCityDataset() *CityDatasetGeocoder {
+	defaultDatasetOnce.Do(func() {
+		defaultDataset = NewCityDatasetGeocoder()
+	})
+	return defaultDataset
+}
+
+func (g *CityDatasetGeocoder) Geocode(ctx context.Context, query GeocodeQuery) (*GeocodeResult, error) {
+	countryCode := g.CountryCode(query.Country)
+
+	candidates := g.cities[normalizePlace(query.City)]
+	var best *datasetCity
+	for _, city := range candidates {
+		if countryCode != "" && city.CountryCode != countryCode {
+			continue
+		}
+		if best == nil {
+			best = city
+		}
+		if query.State != "" && normalizePlace(city.State) == normalizePlace(query.State) {
+			best = city
+			break
+		}
+	}
+
+	if best == nil {
+		if countryCode == "" {
+			return nil, ErrLocationNotFound
+		}
+		return &GeocodeResult{CountryCode: countryCode}, nil
+	}
+
+	return &GeocodeResult{
+		Location:    best.Location,
+		CountryCode: best.CountryCode,
+		Timezone:    best.Timezone,
+	}, nil
+}
+
+// CountryCode maps a country name, common alias or ISO 3166-1 alpha-2 code to the ISO code.
+func (g *CityDatasetGeocoder) CountryCode(country string) string {
+	return g.countries[normalizePlace(country)]
+}
+
+// CountryName returns the English name of the country with the ISO code, or "" when unknown.
+func (g *CityDatasetGeocoder) CountryName(code string) string {
+	return g.names[strings.ToUpper(code)]
+}
+
+// NearestTimezone returns the timezone of the closest known city, used by geocoders
+// whose upstream does not report timezones.
+func (g *CityDatasetGeocoder) NearestTimezone(point *GeoPoint, countryCode string) string {
+	var nearest *datasetCity
+	nearestDistance := 0.0
+	for _, city := range g.all {
+		if countryCode != "" && city.CountryCode != countryCode {
+			continue
+		}
+		distance := point.DistanceKm(city.Location)
+		if nearest == nil || distance < nearestDistance {
+			nearest = city
+			nearestDistance = distance
+		}
+	}
+
+	if nearest == nil {
+		if countryCode != "" {
+			return g.NearestTimezone(point, "")
+		}
+		return ""
+	}
+
+	return nearest.Timezone
+}
+
+// normalizePlace lowercases and strips accents and punctuation so that "Zürich", "zurich"
+// and "St. Louis"/"st louis" compare equal.
+func normalizePlace(value string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(t, value)
+	if err != nil {
+		stripped = value
+	}
+
+	var b strings.Builder
+	lastSpace := true
+	for _, r := range strings.ToLower(stripped) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			lastSpace = false
+		case !lastSpace:
+			b.WriteRune(' ')
+			lastSpace = true
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
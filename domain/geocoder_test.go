@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCityDatasetGeocoder(t *testing.T) {
+	dataset := DefaultCityDataset()
+
+	tests := []struct {
+		name            string
+		query           GeocodeQuery
+		wantErr         error
+		wantLocation    bool
+		wantCountryCode string
+		wantTimezone    string
+	}{
+		{"city and country name", GeocodeQuery{City: "Berlin", Country: "Germany"}, nil, true, "DE", "Europe/Berlin"},
+		{"country alias", GeocodeQuery{City: "London", Country: "UK"}, nil, true, "GB", "Europe/London"},
+		{"country code", GeocodeQuery{City: "Paris", Country: "fr"}, nil, true, "FR", "Europe/Paris"},
+		{"case and accents", GeocodeQuery{City: "bérlin", Country: "deutschland"}, nil, true, "DE", "Europe/Berlin"},
+		{"ambiguous city resolved by country", GeocodeQuery{City: "San Jose", Country: "USA"}, nil, true, "US", "America/Los_Angeles"},
+		{"ambiguous city resolved by state", GeocodeQuery{City: "San Jose", State: "CA"}, nil, true, "US", "America/Los_Angeles"},
+		{"unknown city in known country", GeocodeQuery{City: "Nowhereville", Country: "Germany"}, nil, false, "DE", ""},
+		{"city in another country", GeocodeQuery{City: "Berlin", Country: "France"}, nil, false, "FR", ""},
+		{"unknown city and country", GeocodeQuery{City: "Nowhereville", Country: "Atlantis"}, ErrLocationNotFound, false, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := dataset.Geocode(context.Background(), tt.query)
+			if err != tt.wantErr {
+				t.Fatalf("Geocode() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if (result.Location != nil) != tt.wantLocation {
+				t.Errorf("Geocode() location = %v, want location %v", result.Location, tt.wantLocation)
+			}
+			if result.CountryCode != tt.wantCountryCode {
+				t.Errorf("Geocode() country code = %q, want %q", result.CountryCode, tt.wantCountryCode)
+			}
+			if result.Timezone != tt.wantTimezone {
+				t.Errorf("Geocode() timezone = %q, want %q", result.Timezone, tt.wantTimezone)
+			}
+		})
+	}
+}
+
+func TestNominatimGeocoder(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		wantErr         bool
+		wantCountryCode string
+		wantTimezone    string
+	}{
+		{"place found", http.StatusOK, `[{"lat":"52.5170365","lon":"13.3888599","address":{"country_code":"de"}}]`, false, "DE", "Europe/Berlin"},
+		{"no place falls back to the dataset", http.StatusOK, `[]`, false, "DE", "Europe/Berlin"},
+		{"upstream error", http.StatusServiceUnavailable, ``, true, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query map[string]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = map[string]string{"city": r.URL.Query().Get("city"), "country": r.URL.Query().Get("country")}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			g := NewNominatimGeocoder(server.URL+"/", DefaultCityDataset())
+			result, err := g.Geocode(context.Background(), GeocodeQuery{City: "Berlin", Country: "Germany"})
+			if query["city"] != "Berlin" || query["country"] != "Germany" {
+				t.Errorf("Geocode() sent query %v", query)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Geocode() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if result.Location == nil {
+				t.Fatal("Geocode() returned no location")
+			}
+			if result.CountryCode != tt.wantCountryCode {
+				t.Errorf("Geocode() country code = %q, want %q", result.CountryCode, tt.wantCountryCode)
+			}
+			if result.Timezone != tt.wantTimezone {
+				t.Errorf("Geocode() timezone = %q, want %q", result.Timezone, tt.wantTimezone)
+			}
+		})
+	}
+}
+
+func TestUpdateEngineerPayloadGeocode(t *testing.T) {
+	previous := geocoder
+	SetGeocoder(DefaultCityDataset())
+	defer SetGeocoder(previous)
+
+	berlin := func(timezone string, geocoded bool) *Engineer {
+		return &Engineer{City: "Berlin", Country: "Germany", CountryCode: "DE", Location: NewGeoPoint(52.52, 13.405), Timezone: timezone, TimezoneGeocoded: geocoded}
+	}
+
+	tests := []struct {
+		name            string
+		current         *Engineer
+		payload         *UpdateEngineerPayload
+		wantLocation    bool
+		wantCountryCode string
+		wantTimezone    string
+		wantCleared     []string
+	}{
+		{"location untouched", berlin("Europe/Berlin", true), &UpdateEngineerPayload{Bio: "Hello"}, false, "", "", nil},
+		{"city moves within the country", berlin("", false), &UpdateEngineerPayload{City: "Munich"}, true, "DE", "Europe/Berlin", nil},
+		{"unknown city keeps the country", berlin("", false), &UpdateEngineerPayload{City: "Nowhereville"}, false, "DE", "", []string{"location"}},
+		{"unknown city and country", berlin("", false), &UpdateEngineerPayload{City: "Nowhereville", Country: "Atlantis"}, false, "", "", []string{"location", "country_code"}},
+		{"geocoded timezone follows the city", berlin("Europe/Berlin", true), &UpdateEngineerPayload{City: "London", Country: "UK"}, true, "GB", "Europe/London", nil},
+		{"picked timezone stays when the city moves", berlin("America/New_York", false), &UpdateEngineerPayload{City: "London", Country: "UK"}, true, "GB", "", nil},
+		{"picked timezone stays when only the state changes", berlin("America/New_York", false), &UpdateEngineerPayload{State: "Berlin"}, true, "DE", "", nil},
+		{"geocoded timezone cleared with an unknown city", berlin("Europe/Berlin", true), &UpdateEngineerPayload{City: "Nowhereville"}, false, "DE", "", []string{"location", "timezone", "timezone_geocoded"}},
+		{"picked timezone stays with an unknown city", berlin("America/New_York", false), &UpdateEngineerPayload{City: "Nowhereville"}, false, "DE", "", []string{"location"}},
+		{"picking a timezone stops it following the city", berlin("Europe/Berlin", true), &UpdateEngineerPayload{City: "London", Country: "UK", Timezone: "Europe/Berlin"}, true, "GB", "Europe/Berlin", []string{"timezone_geocoded"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.payload.Geocode(context.Background(), tt.current)
+			if err != nil {
+				t.Fatalf("Geocode() error = %v", err)
+			}
+
+			if (tt.payload.Location != nil) != tt.wantLocation {
+				t.Errorf("Geocode() location = %v, want location %v", tt.payload.Location, tt.wantLocation)
+			}
+			if tt.payload.CountryCode != tt.wantCountryCode {
+				t.Errorf("Geocode() country code = %q, want %q", tt.payload.CountryCode, tt.wantCountryCode)
+			}
+			if tt.payload.Timezone != tt.wantTimezone {
+				t.Errorf("Geocode() timezone = %q, want %q", tt.payload.Timezone, tt.wantTimezone)
+			}
+
+			unset, _ := tt.payload.UpdateDocument()["$unset"].(bson.M)
+			if len(unset) != len(tt.wantCleared) {
+				t.Fatalf("UpdateDocument() unsets %v, want %v", unset, tt.wantCleared)
+			}
+			for _, field := range tt.wantCleared {
+				if _, ok := unset[field]; !ok {
+					t.Errorf("UpdateDocument() doesn't unset %q", field)
+				}
+			}
+		})
+	}
+}
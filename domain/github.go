@@ -0,0 +1,323 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	githubTopLanguages       = 5
+	githubAngularRepos       = 6
+	githubContributionWindow = 90 * 24 * time.Hour
+)
+
+var (
+	ErrGithubUserNotFound = errors.New("github user not found")
+	ErrGithubRateLimited  = errors.New("github rate limit exceeded")
+)
+
+var githubLoginPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+
+// GithubUser, GithubRepository and GithubEvent hold the parts of the GitHub REST API responses
+// the enrichment uses. Their JSON tags match the API, so recorded responses decode as they are.
+type GithubUser struct {
+	Login       string `json:"login"`
+	Name        string `json:"name"`
+	HTMLURL     string `json:"html_url"`
+	PublicRepos int    `json:"public_repos"`
+	Followers   int    `json:"followers"`
+}
+
+type GithubRepository struct {
+	Name            string    `json:"name"`
+	HTMLURL         string    `json:"html_url"`
+	Description     string    `json:"description"`
+	Language        string    `json:"language"`
+	Topics          []string  `json:"topics"`
+	Fork            bool      `json:"fork"`
+	Archived        bool      `json:"archived"`
+	StargazersCount int       `json:"stargazers_count"`
+	PushedAt        time.Time `json:"pushed_at"`
+}
+
+type GithubEvent struct {
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Payload   struct {
+		Action string `json:"action,omitempty"`
+		Size   int    `json:"size,omitempty"`
+	} `json:"payload"`
+}
+
+// GithubClient reads public data of a GitHub user. Repositories are the ones the user owns, and
+// events the public activity GitHub still keeps, which covers at most the last 90 days.
+type GithubClient interface {
+	User(ctx context.Context, login string) (*GithubUser, error)
+	Repositories(ctx context.Context, login string) ([]GithubRepository, error)
+	Events(ctx context.Context, login string) ([]GithubEvent, error)
+}
+
+var githubClient GithubClient
+
+func SetGithubClient(c GithubClient) {
+	githubClient = c
+}
+
+func CurrentGithubClient() GithubClient {
+	if githubClient == nil {
+		githubClient = NewGithubClientFromEnv()
+	}
+	return githubClient
+}
+
+// NewGithubClientFromEnv picks the client configured by GITHUB_CLIENT. The API is queried by
+// default, authenticated with GITHUB_TOKEN when set; "fixture" replays recorded responses from
+// GITHUB_FIXTURES_DIR, or from the ones bundled with the binary. Setting GITHUB_RECORD_DIR records
+// what the API returns there, in the layout the fixture client reads.
+func NewGithubClientFromEnv() GithubClient {
+	switch os.Getenv("GITHUB_CLIENT") {
+	case "fixture":
+		return NewGithubFixtureClient(os.Getenv("GITHUB_FIXTURES_DIR"))
+	default:
+		var client GithubClient = NewGithubHTTPClient(os.Getenv("GITHUB_API_URL"), os.Getenv("GITHUB_TOKEN"))
+		if dir := os.Getenv("GITHUB_RECORD_DIR"); dir != "" {
+			client = &GithubRecorder{Client: client, Dir: dir}
+		}
+		return client
+	}
+}
+
+// GithubStats is what the profile shows of the engineer's GitHub account. It is cached on the
+// engineer and refreshed by a background job.
+type GithubStats struct {
+	Login         string              `bson:"login"`
+	Name          string              `bson:"name,omitempty"`
+	URL           string              `bson:"url"`
+	PublicRepos   int                 `bson:"public_repos"`
+	Followers     int                 `bson:"followers"`
+	Stars         int                 `bson:"stars"`
+	TopLanguages  []LanguageShare     `bson:"top_languages,omitempty"`
+	AngularRepos  []GithubRepoSummary `bson:"angular_repos,omitempty"`
+	Contributions GithubContributions `bson:"contributions"`
+	FetchedAt     time.Time           `bson:"fetched_at"`
+}
+
+// LanguageShare is the number of the user's own repositories mainly written in the language,
+// and the percentage of those repositories it represents.
+type LanguageShare struct {
+	Language string `bson:"language"`
+	Repos    int    `bson:"repos"`
+	Percent  int    `bson:"percent"`
+}
+
+type GithubRepoSummary struct {
+	Name        string    `bson:"name"`
+	URL         string    `bson:"url"`
+	Description string    `bson:"description,omitempty"`
+	Language    string    `bson:"language,omitempty"`
+	Stars       int       `bson:"stars"`
+	PushedAt    time.Time `bson:"pushed_at"`
+}
+
+// GithubContributions counts the public activity since the given time.
+type GithubContributions struct {
+	Commits      int       `bson:"commits"`
+	PullRequests int       `bson:"pull_requests"`
+	Issues       int       `bson:"issues"`
+	Reviews      int       `bson:"reviews"`
+	Since        time.Time `bson:"since"`
+}
+
+// GithubLogin extracts the user name from a GitHub profile URL such as
+// https://github.com/octocat, returning "" when the URL doesn't point at a GitHub user.
+func GithubLogin(profileURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(profileURL))
+	if err != nil {
+		return ""
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
+	if host != "github.com" {
+		return ""
+	}
+
+	login := strings.Split(strings.Trim(parsed.Path, "/"), "/")[0]
+	if !githubLoginPattern.MatchString(login) {
+		return ""
+	}
+
+	return login
+}
+
+// FetchGithubStats gathers the stats of the GitHub user through the client.
+func FetchGithubStats(ctx context.Context, client GithubClient, login string, now time.Time) (*GithubStats, error) {
+	user, err := client.User(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := client.Repositories(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := client.Events(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &GithubStats{
+		Login:         user.Login,
+		Name:          user.Name,
+		URL:           user.HTMLURL,
+		PublicRepos:   user.PublicRepos,
+		Followers:     user.Followers,
+		TopLanguages:  topLanguages(repos),
+		AngularRepos:  angularRepos(repos),
+		Contributions: countContributions(events, now.Add(-githubContributionWindow)),
+		FetchedAt:     now,
+	}
+
+	for _, repo := range repos {
+		if !repo.Fork {
+			stats.Stars += repo.StargazersCount
+		}
+	}
+
+	return stats, nil
+}
+
+// topLanguages ranks languages by the number of repositories, leaving out forks, which say
+// little about what the user writes.
+func topLanguages(repos []GithubRepository) []LanguageShare {
+	counts := map[string]int{}
+	total := 0
+	for _, repo := range repos {
+		if repo.Fork || repo.Language == "" {
+			continue
+		}
+		counts[repo.Language]++
+		total++
+	}
+
+	shares := []LanguageShare{}
+	for language, count := range counts {
+		shares = append(shares, LanguageShare{
+			Language: language,
+			Repos:    count,
+			Percent:  (count*100 + total/2) / total,
+		})
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Repos != shares[j].Repos {
+			return shares[i].Repos > shares[j].Repos
+		}
+		return shares[i].Language < shares[j].Language
+	})
+
+	if len(shares) > githubTopLanguages {
+		shares = shares[:githubTopLanguages]
+	}
+	return shares
+}
+
+// angularRepos picks the user's own repositories about Angular or its ecosystem, the most
+// starred first.
+func angularRepos(repos []GithubRepository) []GithubRepoSummary {
+	summaries := []GithubRepoSummary{}
+	for _, repo := range repos {
+		if repo.Fork || repo.Archived || !isAngularRepository(repo) {
+			continue
+		}
+		summaries = append(summaries, GithubRepoSummary{
+			Name:        repo.Name,
+			URL:         repo.HTMLURL,
+			Description: repo.Description,
+			Language:    repo.Language,
+			Stars:       repo.StargazersCount,
+			PushedAt:    repo.PushedAt,
+		})
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].Stars != summaries[j].Stars {
+			return summaries[i].Stars > summaries[j].Stars
+		}
+		return summaries[i].PushedAt.After(summaries[j].PushedAt)
+	})
+
+	if len(summaries) > githubAngularRepos {
+		summaries = summaries[:githubAngularRepos]
+	}
+	return summaries
+}
+
+var angularWords = map[string]bool{
+	"ng":       true,
+	"ngxs":     true,
+	"rxjs":     true,
+	"nx":       true,
+	"analogjs": true,
+}
+
+var angularPrefixes = []string{"angular", "ngx", "ngrx"}
+
+// isAngularRepository matches whole words of the name, topics and description, so that "ng-zorro"
+// or "ngx-charts" count but "nginx" doesn't.
+func isAngularRepository(repo GithubRepository) bool {
+	text := strings.Join(append([]string{repo.Name, repo.Description}, repo.Topics...), " ")
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+
+	for _, word := range words {
+		if angularWords[word] {
+			return true
+		}
+		for _, prefix := range angularPrefixes {
+			if strings.HasPrefix(word, prefix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func countContributions(events []GithubEvent, since time.Time) GithubContributions {
+	contributions := GithubContributions{Since: since}
+	for _, event := range events {
+		if event.CreatedAt.Before(since) {
+			continue
+		}
+
+		switch event.Type {
+		case "PushEvent":
+			// recent API versions no longer report the number of commits of a push
+			if event.Payload.Size > 0 {
+				contributions.Commits += event.Payload.Size
+			} else {
+				contributions.Commits++
+			}
+		case "PullRequestEvent":
+			if event.Payload.Action == "opened" {
+				contributions.PullRequests++
+			}
+		case "IssuesEvent":
+			if event.Payload.Action == "opened" {
+				contributions.Issues++
+			}
+		case "PullRequestReviewEvent":
+			contributions.Reviews++
+		}
+	}
+
+	return contributions
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultGithubAPIURL = "https://api.github.com"
+	githubPageSize      = 100
+	githubMaxPages      = 3
+)
+
+// GithubHTTPClient queries the GitHub REST API. Without a token GitHub allows 60 requests an
+// hour per IP address, which is enough for a few profiles at a time; with one, 5000.
+type GithubHTTPClient struct {
+	BaseURL string
+	Token   string
+	Client  *http.Client
+}
+
+func NewGithubHTTPClient(baseURL, token string) *GithubHTTPClient {
+	if baseURL == "" {
+		baseURL = defaultGithubAPIURL
+	}
+
+	return &GithubHTTPClient{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Token:   token,
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *GithubHTTPClient) User(ctx context.Context, login string) (*GithubUser, error) {
+	var user GithubUser
+	err := c.get(ctx, "/users/"+url.PathEscape(login), url.Values{}, &user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Repositories reads the most recently pushed repositories, up to githubMaxPages pages.
+func (c *GithubHTTPClient) Repositories(ctx context.Context, login string) ([]GithubRepository, error) {
+	repos := []GithubRepository{}
+	for page := 1; page <= githubMaxPages; page++ {
+		params := url.Values{}
+		params.Set("type", "owner")
+		params.Set("sort", "pushed")
+		params.Set("per_page", strconv.Itoa(githubPageSize))
+		params.Set("page", strconv.Itoa(page))
+
+		var batch []GithubRepository
+		err := c.get(ctx, "/users/"+url.PathEscape(login)+"/repos", params, &batch)
+		if err != nil {
+			return nil, err
+		}
+
+		repos = append(repos, batch...)
+		if len(batch) < githubPageSize {
+			break
+		}
+	}
+	return repos, nil
+}
+
+// Events reads the public events, newest first. GitHub serves 300 of them at most.
+func (c *GithubHTTPClient) Events(ctx context.Context, login string) ([]GithubEvent, error) {
+	events := []GithubEvent{}
+	for page := 1; page <= githubMaxPages; page++ {
+		params := url.Values{}
+		params.Set("per_page", strconv.Itoa(githubPageSize))
+		params.Set("page", strconv.Itoa(page))
+
+		var batch []GithubEvent
+		err := c.get(ctx, "/users/"+url.PathEscape(login)+"/events/public", params, &batch)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, batch...)
+		if len(batch) < githubPageSize {
+			break
+		}
+	}
+	return events, nil
+}
+
+func (c *GithubHTTPClient) get(ctx context.Context, path string, params url.Values, into interface{}) error {
+	endpoint := c.BaseURL + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Accept", "application/vnd.github+json")
+	req.Header.Add("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Add("User-Agent", "angular-talents-backend")
+	if c.Token != "" {
+		req.Header.Add("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrGithubUserNotFound
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		return ErrGithubRateLimited
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("github returned status %d for %s", resp.StatusCode, path)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(into)
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed data/github
+var githubFixtures embed.FS
+
+// GithubFixtureClient replays responses recorded by GithubRecorder, so that enrichment can run
+// in tests and local development without network access or rate limits. Each user has a
+// directory named after the lowercased login holding user.json, repos.json and events.json;
+// a missing user.json means the user doesn't exist, missing lists are empty.
+type GithubFixtureClient struct {
+	Files fs.FS
+}
+
+// NewGithubFixtureClient reads the fixtures from the directory, or the bundled ones when it is
+// empty.
+func NewGithubFixtureClient(dir string) *GithubFixtureClient {
+	if dir == "" {
+		files, err := fs.Sub(githubFixtures, "data/github")
+		if err != nil {
+			panic(err)
+		}
+		return &GithubFixtureClient{Files: files}
+	}
+
+	return &GithubFixtureClient{Files: os.DirFS(dir)}
+}
+
+func (c *GithubFixtureClient) User(ctx context.Context, login string) (*GithubUser, error) {
+	var user GithubUser
+	found, err := c.read(login, "user.json", &user)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrGithubUserNotFound
+	}
+	return &user, nil
+}
+
+func (c *GithubFixtureClient) Repositories(ctx context.Context, login string) ([]GithubRepository, error) {
+	repos := []GithubRepository{}
+	_, err := c.read(login, "repos.json", &repos)
+	if err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+func (c *GithubFixtureClient) Events(ctx context.Context, login string) ([]GithubEvent, error) {
+	events := []GithubEvent{}
+	_, err := c.read(login, "events.json", &events)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (c *GithubFixtureClient) read(login, name string, into interface{}) (bool, error) {
+	if !githubLoginPattern.MatchString(login) {
+		return false, nil
+	}
+
+	data, err := fs.ReadFile(c.Files, path.Join(strings.ToLower(login), name))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, json.Unmarshal(data, into)
+}
+
+// GithubRecorder passes requests on to another client and writes the responses to Dir in the
+// layout GithubFixtureClient reads.
+type GithubRecorder struct {
+	Client GithubClient
+	Dir    string
+}
+
+func (r *GithubRecorder) User(ctx context.Context, login string) (*GithubUser, error) {
+	user, err := r.Client.User(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	return user, r.write(login, "user.json", user)
+}
+
+func (r *GithubRecorder) Repositories(ctx context.Context, login string) ([]GithubRepository, error) {
+	repos, err := r.Client.Repositories(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	return repos, r.write(login, "repos.json", repos)
+}
+
+func (r *GithubRecorder) Events(ctx context.Context, login string) ([]GithubEvent, error) {
+	events, err := r.Client.Events(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	return events, r.write(login, "events.json", events)
+}
+
+func (r *GithubRecorder) write(login, name string, value interface{}) error {
+	if !githubLoginPattern.MatchString(login) {
+		return ErrGithubUserNotFound
+	}
+
+	dir := filepath.Join(r.Dir, strings.ToLower(login))
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, name), append(data, '\n'), 0o644)
+}
@@ -0,0 +1,242 @@
+package domain
+
+import (
+	"context"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const demoGithubLogin = "ngtalents-demo"
+
+var githubTestNow = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+func TestFetchGithubStatsFromFixtures(t *testing.T) {
+	stats, err := FetchGithubStats(context.Background(), NewGithubFixtureClient(""), demoGithubLogin, githubTestNow)
+	if err != nil {
+		t.Fatalf("FetchGithubStats() error = %v", err)
+	}
+
+	if stats.Login != demoGithubLogin || stats.Name != "Demo Engineer" || stats.URL != "https://github.com/ngtalents-demo" {
+		t.Errorf("FetchGithubStats() user = %q %q %q", stats.Login, stats.Name, stats.URL)
+	}
+	if stats.PublicRepos != 6 || stats.Followers != 42 {
+		t.Errorf("FetchGithubStats() public repos = %d, followers = %d", stats.PublicRepos, stats.Followers)
+	}
+
+	// the forked repository's stars aren't the user's
+	if stats.Stars != 145 {
+		t.Errorf("FetchGithubStats() stars = %d, want 145", stats.Stars)
+	}
+
+	wantLanguages := []LanguageShare{
+		{Language: "TypeScript", Repos: 2, Percent: 40},
+		{Language: "Go", Repos: 1, Percent: 20},
+		{Language: "JavaScript", Repos: 1, Percent: 20},
+		{Language: "Shell", Repos: 1, Percent: 20},
+	}
+	if !reflect.DeepEqual(stats.TopLanguages, wantLanguages) {
+		t.Errorf("FetchGithubStats() top languages = %+v, want %+v", stats.TopLanguages, wantLanguages)
+	}
+
+	// nginx isn't Angular, and neither forks nor archived repositories count
+	angularRepos := []string{}
+	for _, repo := range stats.AngularRepos {
+		angularRepos = append(angularRepos, repo.Name)
+	}
+	if want := []string{"ngx-virtual-table", "signal-store-playground"}; !reflect.DeepEqual(angularRepos, want) {
+		t.Errorf("FetchGithubStats() angular repos = %v, want %v", angularRepos, want)
+	}
+
+	wantContributions := GithubContributions{
+		Commits: 5,
+		PullRequests: 1,
+		Issues: 1,
+		Reviews: 1,
+		Since: githubTestNow.Add(-githubContributionWindow),
+	}
+	if stats.Contributions != wantContributions {
+		t.Errorf("FetchGithubStats() contributions = %+v, want %+v", stats.Contributions, wantContributions)
+	}
+}
+
+func TestGithubFixtureClientUnknownUsers(t *testing.T) {
+	client := NewGithubFixtureClient("")
+
+	tests := []struct {
+		name    string
+		login   string
+		wantErr error
+	}{
+		{"login is case insensitive", "NgTalents-Demo", nil},
+		{"unknown user", "nobody-here", ErrGithubUserNotFound},
+		{"path outside the fixtures", "../github", ErrGithubUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.User(context.Background(), tt.login)
+			if err != tt.wantErr {
+				t.Errorf("User() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestGithubHTTPClientAgainstFixtures serves the fixtures as the GitHub API would and checks that
+// the HTTP client gathers the same stats as the fixture client.
+func TestGithubHTTPClientAgainstFixtures(t *testing.T) {
+	files, err := fs.Sub(githubFixtures, "data/github/"+demoGithubLogin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	routes := map[string]string{
+		"/users/" + demoGithubLogin: "user.json",
+		"/users/" + demoGithubLogin + "/repos": "repos.json",
+		"/users/" + demoGithubLogin + "/events/public": "events.json",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("%s sent without the token", r.URL.Path)
+		}
+		if r.URL.Path == "/users/rate-limited" {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		name, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		data, err := fs.ReadFile(files, name)
+		if err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	client := NewGithubHTTPClient(server.URL+"/", "test-token")
+
+	got, err := FetchGithubStats(context.Background(), client, demoGithubLogin, githubTestNow)
+	if err != nil {
+		t.Fatalf("FetchGithubStats() error = %v", err)
+	}
+	want, err := FetchGithubStats(context.Background(), NewGithubFixtureClient(""), demoGithubLogin, githubTestNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FetchGithubStats() = %+v, want %+v", got, want)
+	}
+
+	_, err = client.User(context.Background(), "nobody-here")
+	if err != ErrGithubUserNotFound {
+		t.Errorf("User() error = %v, want %v", err, ErrGithubUserNotFound)
+	}
+
+	_, err = client.User(context.Background(), "rate-limited")
+	if err != ErrGithubRateLimited {
+		t.Errorf("User() error = %v, want %v", err, ErrGithubRateLimited)
+	}
+}
+
+func TestGithubRecorderReplaysThroughFixtureClient(t *testing.T) {
+	dir := t.TempDir()
+	recorder := &GithubRecorder{Client: NewGithubFixtureClient(""), Dir: dir}
+
+	recorded, err := FetchGithubStats(context.Background(), recorder, "NgTalents-Demo", githubTestNow)
+	if err != nil {
+		t.Fatalf("FetchGithubStats() through the recorder error = %v", err)
+	}
+
+	replayed, err := FetchGithubStats(context.Background(), NewGithubFixtureClient(dir), demoGithubLogin, githubTestNow)
+	if err != nil {
+		t.Fatalf("FetchGithubStats() from the recording error = %v", err)
+	}
+
+	if !reflect.DeepEqual(recorded, replayed) {
+		t.Errorf("replayed stats = %+v, want %+v", replayed, recorded)
+	}
+}
+
+func TestGithubLogin(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/ngtalents-demo", "ngtalents-demo"},
+		{"https://www.github.com/ngtalents-demo/", "ngtalents-demo"},
+		{"https://github.com/ngtalents-demo/ngx-virtual-table", "ngtalents-demo"},
+		{" https://GitHub.com/NgTalents-Demo ", "NgTalents-Demo"},
+		{"https://gitlab.com/ngtalents-demo", ""},
+		{"https://github.com/", ""},
+		{"https://github.com/-invalid", ""},
+		{"not a url", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.url), func(t *testing.T) {
+			if got := GithubLogin(tt.url); got != tt.want {
+				t.Errorf("GithubLogin(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGithubStatsFollowLinkedAccount(t *testing.T) {
+	stats := &GithubStats{Login: demoGithubLogin}
+
+	tests := []struct {
+		name        string
+		current     string
+		github      string
+		wantCleared bool
+		wantShown   bool
+	}{
+		{"same account", "https://github.com/ngtalents-demo", "https://github.com/NgTalents-Demo/", false, true},
+		{"other account", "https://github.com/ngtalents-demo", "https://github.com/someone-else", true, false},
+		{"not updated", "https://github.com/ngtalents-demo", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := &UpdateEngineerPayload{Github: tt.github}
+			payload.ClearStaleGithubStats(&Engineer{Github: tt.current})
+
+			unset, _ := payload.UpdateDocument()["$unset"].(bson.M)
+			_, cleared := unset["github_stats"]
+			if cleared != tt.wantCleared {
+				t.Errorf("UpdateDocument() unsets github_stats = %v, want %v", cleared, tt.wantCleared)
+			}
+
+			// stats fetched for the previous account before the update went through
+			github := tt.current
+			if tt.github != "" {
+				github = tt.github
+			}
+			engineer := &Engineer{Github: github, GithubStats: stats}
+			view, err := (&Viewer{UserID: uuid.New(), IsMember: true}).EngineerView(engineer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, shown := view["GithubStats"]; shown != tt.wantShown {
+				t.Errorf("EngineerView() shows stats = %v, want %v", shown, tt.wantShown)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ProfileTypeEngineer  = "engineer"
+	ProfileTypeRecruiter = "recruiter"
+)
+
+var (
+	ErrUnknownProfileVersion = errors.New("unknown profile version")
+	ErrProfileVersionChanged = errors.New("profile changed while being restored")
+)
+
+// HistoryFields lists, per profile type, the fields whose changes are recorded and can be rolled
+// back. Uploaded images are left out: the files of a replaced image are deleted, so an old
+// version of them can't be brought back.
+var HistoryFields = map[string][]string{
+	ProfileTypeEngineer: {
+		"Firstname", "Lastname", "Tagline", "City", "State", "Country", "CountryCode", "Timezone",
+		"Location", "Bio", "SearchStatus", "RoleType", "RoleLevel", "Website", "Github", "LinkedIn",
+		"Twitter", "StackOverflow", "FieldVisibility", "BlockedCompanies", "Experience", "Education",
+		"Projects",
+	},
+	ProfileTypeRecruiter: {
+		"Firstname", "Lastname", "Company", "Role", "Bio", "Website", "LinkedIn", "HideViewActivity",
+	},
+}
+
+// ProfileRevision records the changes that took a profile to a version. Versions increase with
+// every update but may skip numbers, as updates that change none of the HistoryFields leave no
+// revision. Version 0 is the profile as it was created.
+type ProfileRevision struct {
+	ID uuid.UUID			`bson:"_id"`
+	ProfileType string		`bson:"profile_type"`
+	ProfileID uuid.UUID		`bson:"profile_id"`
+	Version int				`bson:"version"`
+	Changes []FieldChange	`bson:"changes"`
+	RestoredFrom *int		`bson:"restored_from,omitempty"`
+	ChangedBy uuid.UUID		`bson:"changed_by"`
+	CreatedAt time.Time		`bson:"created_at"`
+}
+
+// FieldChange holds the values of a field, keyed like the profile JSON, before and after a
+// revision. Values are kept in their JSON form so they read the same as the profile itself.
+type FieldChange struct {
+	Field string			`bson:"field"`
+	Before interface{}		`bson:"before"`
+	After interface{}		`bson:"after"`
+}
+
+// UnmarshalBSON turns the values back into their JSON form, as documents and arrays read from
+// Mongo would otherwise be decoded as primitive.D and primitive.A.
+func (c *FieldChange) UnmarshalBSON(data []byte) error {
+	type storedChange FieldChange
+	var stored storedChange
+	err := bson.Unmarshal(data, &stored)
+	if err != nil {
+		return err
+	}
+
+	*c = FieldChange{Field: stored.Field, Before: plainValue(stored.Before), After: plainValue(stored.After)}
+	return nil
+}
+
+func plainValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case primitive.D:
+		plain := map[string]interface{}{}
+		for _, element := range v {
+			plain[element.Key] = plainValue(element.Value)
+		}
+		return plain
+	case primitive.M:
+		plain := map[string]interface{}{}
+		for key, element := range v {
+			plain[key] = plainValue(element)
+		}
+		return plain
+	case primitive.A:
+		plain := make([]interface{}, len(v))
+		for i, element := range v {
+			plain[i] = plainValue(element)
+		}
+		return plain
+	default:
+		return v
+	}
+}
+
+// NewProfileRevision compares the profile before and after an update, returning nil when none of
+// the recorded fields changed. The user making the change is taken from the context.
+func NewProfileRevision(ctx context.Context, profileType string, profileID uuid.UUID, version int, before, after interface{}, restoredFrom *int) (*ProfileRevision, error) {
+	beforeFields, err := profileJSON(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := profileJSON(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []FieldChange{}
+	for _, field := range HistoryFields[profileType] {
+		if !reflect.DeepEqual(beforeFields[field], afterFields[field]) {
+			changes = append(changes, FieldChange{Field: field, Before: beforeFields[field], After: afterFields[field]})
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	changedBy, _ := ctx.Value("userID").(uuid.UUID)
+
+	return &ProfileRevision{
+		ID: uuid.New(),
+		ProfileType: profileType,
+		ProfileID: profileID,
+		Version: version,
+		Changes: changes,
+		RestoredFrom: restoredFrom,
+		ChangedBy: changedBy,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// RestoreUpdate builds the Mongo update that takes the profile back to the given version, by
+// undoing the revisions made since, newest first. Fields that end up empty are unset, like they
+// would be on a profile that never had them. It returns nil when there is nothing to undo.
+func RestoreUpdate(profileType string, current interface{}, version int, revisions []*ProfileRevision) (bson.M, error) {
+	fields, err := profileJSON(current)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Version > revisions[j].Version
+	})
+
+	restored := map[string]bool{}
+	for _, revision := range revisions {
+		if revision.Version <= version {
+			continue
+		}
+		for _, change := range revision.Changes {
+			fields[change.Field] = change.Before
+			restored[change.Field] = true
+		}
+	}
+
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// decoding into a fresh profile of the same type gives the restored values their Go types back
+	target := reflect.New(reflect.TypeOf(current).Elem()).Interface()
+	err = json.Unmarshal(raw, target)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := bson.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var values bson.M
+	err = bson.Unmarshal(document, &values)
+	if err != nil {
+		return nil, err
+	}
+
+	set := bson.M{}
+	unset := bson.M{}
+	keys := bsonKeys(reflect.TypeOf(current).Elem())
+	for _, field := range HistoryFields[profileType] {
+		if !restored[field] {
+			continue
+		}
+
+		key := keys[field]
+		if value, ok := values[key]; ok {
+			set[key] = value
+		} else {
+			unset[key] = ""
+		}
+	}
+
+	if len(restored) == 0 {
+		return nil, nil
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update, nil
+}
+
+func profileJSON(profile interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	err = json.Unmarshal(raw, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("profile is not a JSON object: %w", err)
+	}
+
+	return fields, nil
+}
+
+// bsonKeys maps the Go field names of a struct to the keys they are stored under.
+func bsonKeys(t reflect.Type) map[string]string {
+	keys := map[string]string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := strings.Split(field.Tag.Get("bson"), ",")[0]
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+		keys[field.Name] = key
+	}
+	return keys
+}
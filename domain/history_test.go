@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestProfileHistoryRestoresProfileLinks(t *testing.T) {
+	tests := []struct {
+		name        string
+		profileType string
+		before      interface{}
+		after       interface{}
+		wantFields  []string
+		wantSet     bson.M
+	}{
+		{
+			"engineer links wiped",
+			ProfileTypeEngineer,
+			&Engineer{Github: "https://github.com/ngtalents-demo", LinkedIn: "https://www.linkedin.com/in/ngtalents-demo"},
+			&Engineer{},
+			[]string{"Github", "LinkedIn"},
+			bson.M{"github": "https://github.com/ngtalents-demo", "linkedin": "https://www.linkedin.com/in/ngtalents-demo"},
+		},
+		{
+			"recruiter linkedin wiped",
+			ProfileTypeRecruiter,
+			&Recruiter{LinkedIn: "https://www.linkedin.com/in/rita-recruiter"},
+			&Recruiter{},
+			[]string{"LinkedIn"},
+			bson.M{"linkedin": "https://www.linkedin.com/in/rita-recruiter"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revision, err := NewProfileRevision(context.Background(), tt.profileType, uuid.New(), 1, tt.before, tt.after, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if revision == nil {
+				t.Fatal("NewProfileRevision() recorded nothing")
+			}
+
+			fields := []string{}
+			for _, change := range revision.Changes {
+				fields = append(fields, change.Field)
+			}
+			if len(fields) != len(tt.wantFields) {
+				t.Fatalf("NewProfileRevision() changes = %v, want %v", fields, tt.wantFields)
+			}
+			for i := range fields {
+				if fields[i] != tt.wantFields[i] {
+					t.Errorf("NewProfileRevision() changes = %v, want %v", fields, tt.wantFields)
+				}
+			}
+
+			update, err := RestoreUpdate(tt.profileType, tt.after, 0, []*ProfileRevision{revision})
+			if err != nil {
+				t.Fatal(err)
+			}
+			set := update["$set"].(bson.M)
+			for key, want := range tt.wantSet {
+				if set[key] != want {
+					t.Errorf("RestoreUpdate() sets %s = %v, want %v", key, set[key], want)
+				}
+			}
+		})
+	}
+}
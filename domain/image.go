@@ -0,0 +1,453 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxImageUploadBytes caps the size of uploaded avatars and logos.
+const MaxImageUploadBytes = 5 << 20
+
+// maxImagePixels guards against small files that decode into huge bitmaps.
+const maxImagePixels = 24_000_000
+
+var (
+	ErrUnsupportedImage = errors.New("image must be a JPEG, PNG or GIF file")
+	ErrImageTooLarge    = fmt.Errorf("image must not exceed %d megapixels", maxImagePixels/1_000_000)
+)
+
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// ImageSpec describes how an uploaded image is turned into thumbnails. Square images are
+// center-cropped, others are scaled to fit within each size.
+type ImageSpec struct {
+	Prefix      string
+	Sizes       []int
+	Square      bool
+	ContentType string
+}
+
+// Avatars are photos, so they are stored as JPEG; logos keep their transparency as PNG.
+var (
+	AvatarImageSpec = ImageSpec{Prefix: "avatars", Sizes: []int{64, 128, 256}, Square: true, ContentType: "image/jpeg"}
+	LogoImageSpec   = ImageSpec{Prefix: "logos", Sizes: []int{64, 128, 256}, ContentType: "image/png"}
+)
+
+// UploadedImage records the stored thumbnails of an upload, keyed by size, and the storage keys
+// to delete once the image is replaced.
+type UploadedImage struct {
+	Thumbnails map[string]string	`bson:"thumbnails,required"`
+	Keys []string					`bson:"keys,required" json:"-"`
+	UploadedAt time.Time			`bson:"uploaded_at,required"`
+}
+
+type Thumbnail struct {
+	Size int
+	Data []byte
+}
+
+// Process checks the uploaded bytes and renders the thumbnails. The content type is sniffed
+// rather than trusted from the request, and re-encoding drops EXIF and any other metadata once
+// the orientation it records has been applied.
+func (s ImageSpec) Process(data []byte) ([]*Thumbnail, error) {
+	if !allowedImageTypes[http.DetectContentType(data)] {
+		return nil, ErrUnsupportedImage
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	src := image.NewNRGBA(image.Rect(0, 0, decoded.Bounds().Dx(), decoded.Bounds().Dy()))
+	draw.Draw(src, src.Bounds(), decoded, decoded.Bounds().Min, draw.Src)
+	src = orientImage(src, exifOrientation(data))
+
+	if s.Square {
+		src = cropSquare(src)
+	}
+
+	thumbnails := []*Thumbnail{}
+	for _, size := range s.Sizes {
+		width, height := fitWithin(src.Rect.Dx(), src.Rect.Dy(), size)
+
+		encoded, err := s.encode(resizeImage(src, width, height))
+		if err != nil {
+			return nil, err
+		}
+
+		thumbnails = append(thumbnails, &Thumbnail{Size: size, Data: encoded})
+	}
+
+	return thumbnails, nil
+}
+
+// Store uploads the thumbnails under a fresh prefix, so that cached copies of a previous image
+// are never served, and returns the URL of the largest one along with the stored image.
+func (s ImageSpec) Store(ctx context.Context, storage BlobStorage, ownerID uuid.UUID, thumbnails []*Thumbnail) (string, *UploadedImage, error) {
+	uploaded := &UploadedImage{
+		Thumbnails: map[string]string{},
+		Keys: []string{},
+		UploadedAt: time.Now(),
+	}
+
+	extension := ".png"
+	if s.ContentType == "image/jpeg" {
+		extension = ".jpg"
+	}
+
+	uploadID := uuid.New()
+	mainURL := ""
+	largest := 0
+	for _, thumbnail := range thumbnails {
+		key := fmt.Sprintf("%s/%s/%s/%d%s", s.Prefix, ownerID, uploadID, thumbnail.Size, extension)
+
+		url, err := storage.Put(ctx, key, s.ContentType, thumbnail.Data)
+		if err != nil {
+			DeleteUploadedImage(ctx, storage, uploaded)
+			return "", nil, err
+		}
+
+		uploaded.Keys = append(uploaded.Keys, key)
+		uploaded.Thumbnails[strconv.Itoa(thumbnail.Size)] = url
+		if thumbnail.Size > largest {
+			largest = thumbnail.Size
+			mainURL = url
+		}
+	}
+
+	return mainURL, uploaded, nil
+}
+
+// DeleteUploadedImage removes every stored file of the image, carrying on past failures so that
+// as little as possible is left behind.
+func DeleteUploadedImage(ctx context.Context, storage BlobStorage, uploaded *UploadedImage) error {
+	if uploaded == nil {
+		return nil
+	}
+
+	var firstErr error
+	for _, key := range uploaded.Keys {
+		err := storage.Delete(ctx, key)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (s ImageSpec) encode(img *image.NRGBA) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if s.ContentType == "image/jpeg" {
+		// JPEG has no alpha channel, so transparent areas are flattened onto white
+		flat := image.NewRGBA(img.Rect)
+		draw.Draw(flat, flat.Rect, &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+		draw.Draw(flat, flat.Rect, img, img.Rect.Min, draw.Over)
+
+		err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: 85})
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	err := encoder.Encode(&buf, img)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fitWithin scales the dimensions down to fit a size×size box, never enlarging small images.
+func fitWithin(width, height, size int) (int, int) {
+	if width <= size && height <= size {
+		return width, height
+	}
+
+	if width >= height {
+		return size, maxInt(1, int(math.Round(float64(height)*float64(size)/float64(width))))
+	}
+	return maxInt(1, int(math.Round(float64(width)*float64(size)/float64(height)))), size
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func cropSquare(src *image.NRGBA) *image.NRGBA {
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+	if width == height {
+		return src
+	}
+
+	side := width
+	if height < side {
+		side = height
+	}
+
+	x0 := src.Rect.Min.X + (width-side)/2
+	y0 := src.Rect.Min.Y + (height-side)/2
+	return src.SubImage(image.Rect(x0, y0, x0+side, y0+side)).(*image.NRGBA)
+}
+
+type resampleWeight struct {
+	index  int
+	weight float64
+}
+
+// resampleWeights computes a triangle filter for each destination pixel. When shrinking, the
+// filter is widened to the scale factor so that every source pixel contributes, which avoids the
+// aliasing of nearest-neighbour or plain bilinear sampling.
+func resampleWeights(srcSize, dstSize int) [][]resampleWeight {
+	scale := float64(srcSize) / float64(dstSize)
+	support := 1.0
+	if scale > 1 {
+		support = scale
+	}
+
+	weights := make([][]resampleWeight, dstSize)
+	for i := range weights {
+		center := (float64(i)+0.5)*scale - 0.5
+		start := int(math.Floor(center - support))
+		end := int(math.Ceil(center + support))
+
+		total := 0.0
+		for j := start; j <= end; j++ {
+			distance := math.Abs(float64(j)-center) / support
+			if distance >= 1 {
+				continue
+			}
+
+			index := j
+			if index < 0 {
+				index = 0
+			}
+			if index >= srcSize {
+				index = srcSize - 1
+			}
+
+			weights[i] = append(weights[i], resampleWeight{index: index, weight: 1 - distance})
+			total += 1 - distance
+		}
+
+		for k := range weights[i] {
+			weights[i][k].weight /= total
+		}
+	}
+
+	return weights
+}
+
+// resizeImage resamples in two separable passes over premultiplied colors, so that transparent
+// pixels don't bleed their color into the edges of logos.
+func resizeImage(src *image.NRGBA, width, height int) *image.NRGBA {
+	srcWidth, srcHeight := src.Rect.Dx(), src.Rect.Dy()
+
+	columns := resampleWeights(srcWidth, width)
+	horizontal := make([]float64, width*srcHeight*4)
+	for y := 0; y < srcHeight; y++ {
+		row := src.Pix[y*src.Stride : y*src.Stride+srcWidth*4]
+		for x, taps := range columns {
+			var r, g, b, a float64
+			for _, tap := range taps {
+				pixel := row[tap.index*4 : tap.index*4+4]
+				alpha := float64(pixel[3]) * tap.weight
+				r += float64(pixel[0]) * alpha
+				g += float64(pixel[1]) * alpha
+				b += float64(pixel[2]) * alpha
+				a += alpha
+			}
+
+			offset := (y*width + x) * 4
+			horizontal[offset], horizontal[offset+1], horizontal[offset+2], horizontal[offset+3] = r, g, b, a
+		}
+	}
+
+	rows := resampleWeights(srcHeight, height)
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y, taps := range rows {
+		for x := 0; x < width; x++ {
+			var r, g, b, a float64
+			for _, tap := range taps {
+				offset := (tap.index*width + x) * 4
+				r += horizontal[offset] * tap.weight
+				g += horizontal[offset+1] * tap.weight
+				b += horizontal[offset+2] * tap.weight
+				a += horizontal[offset+3] * tap.weight
+			}
+
+			pixel := dst.Pix[y*dst.Stride+x*4 : y*dst.Stride+x*4+4]
+			if a <= 0 {
+				continue
+			}
+			pixel[0] = clampChannel(r / a)
+			pixel[1] = clampChannel(g / a)
+			pixel[2] = clampChannel(b / a)
+			pixel[3] = clampChannel(a)
+		}
+	}
+
+	return dst
+}
+
+func clampChannel(value float64) uint8 {
+	if value <= 0 {
+		return 0
+	}
+	if value >= 255 {
+		return 255
+	}
+	return uint8(value + 0.5)
+}
+
+// orientImage applies an EXIF orientation (1 to 8) so that the image displays upright once the
+// metadata has been dropped.
+func orientImage(src *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = width-1-x, y
+			case 3:
+				sx, sy = width-1-x, height-1-y
+			case 4:
+				sx, sy = x, height-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, height-1-x
+			case 7:
+				sx, sy = width-1-y, height-1-x
+			case 8:
+				sx, sy = width-1-y, x
+			}
+
+			copy(dst.Pix[y*dst.Stride+x*4:y*dst.Stride+x*4+4], src.Pix[sy*src.Stride+sx*4:sy*src.Stride+sx*4+4])
+		}
+	}
+
+	return dst
+}
+
+// exifOrientation reads the orientation tag from the EXIF block of a JPEG, returning 1 (upright)
+// when there is none or it can't be parsed.
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	offset := 2
+	for offset+4 <= len(data) {
+		if data[offset] != 0xFF {
+			return 1
+		}
+
+		marker := data[offset+1]
+		// start of scan: the metadata segments are over
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[offset+2 : offset+4]))
+		if length < 2 || offset+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+
+		offset += 2 + length
+	}
+
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		// 0x0112 is the orientation tag, stored as a SHORT in the first bytes of the value field
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+
+	return 1
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	InterviewStatusPending   = "pending"
+	InterviewStatusConfirmed = "confirmed"
+	InterviewStatusDeclined  = "declined"
+)
+
+const (
+	MinInterviewNotice  = 2 * time.Hour
+	MaxInterviewHorizon = 90 * 24 * time.Hour
+)
+
+// InterviewRequest is a recruiter asking an engineer for a slot within their availability.
+// Pending and confirmed requests both hold the slot.
+type InterviewRequest struct {
+	ID uuid.UUID				`bson:"_id,required"`
+	RecruiterID uuid.UUID		`bson:"recruiter_id,required"`
+	RecruiterUserID uuid.UUID	`bson:"recruiter_user_id,required"`
+	RecruiterName string		`bson:"recruiter_name,required"`
+	Company string				`bson:"company,omitempty"`
+	EngineerID uuid.UUID		`bson:"engineer_id,required"`
+	EngineerUserID uuid.UUID	`bson:"engineer_user_id,required"`
+	EngineerName string			`bson:"engineer_name,required"`
+	Start time.Time				`bson:"start,required"`
+	End time.Time				`bson:"end,required"`
+	Message string				`bson:"message,omitempty"`
+	Status string				`bson:"status,required"`
+	CreatedAt time.Time			`bson:"created_at,required"`
+	RespondedAt *time.Time		`bson:"responded_at,omitempty"`
+}
+
+type CreateInterviewRequestPayload struct {
+	Start time.Time			`json:"start" validate:"required"`
+	DurationMinutes int		`json:"durationMinutes" validate:"required,oneof=15 30 45 60 90"`
+	Message string			`json:"message,omitempty" validate:"omitempty,max=2000"`
+}
+
+type RespondInterviewRequestPayload struct {
+	Status string			`json:"status" validate:"required,oneof=confirmed declined"`
+}
+
+// NewInterviewRequest checks the requested time is far enough ahead but not too far, and that
+// it lies on the minute. The engineer's name is kept as far as the recruiter's viewer may see it,
+// since it's shown to the recruiter and written into the invite.
+func (p *CreateInterviewRequestPayload) NewInterviewRequest(recruiter *Recruiter, engineer *Engineer, viewer *Viewer, now time.Time) (*InterviewRequest, error) {
+	start := p.Start.UTC()
+	if start.Second() != 0 || start.Nanosecond() != 0 {
+		return nil, fmt.Errorf("start must be on the minute")
+	}
+	if start.Before(now.Add(MinInterviewNotice)) {
+		return nil, fmt.Errorf("interviews must be requested at least %s ahead", MinInterviewNotice)
+	}
+	if start.After(now.Add(MaxInterviewHorizon)) {
+		return nil, fmt.Errorf("interviews can't be requested more than %d days ahead", int(MaxInterviewHorizon.Hours()/24))
+	}
+
+	return &InterviewRequest{
+		ID: uuid.New(),
+		RecruiterID: recruiter.ID,
+		RecruiterUserID: recruiter.UserID,
+		RecruiterName: recruiter.Firstname + " " + recruiter.Lastname,
+		Company: recruiter.Company,
+		EngineerID: engineer.ID,
+		EngineerUserID: engineer.UserID,
+		EngineerName: viewer.EngineerName(engineer),
+		Start: start,
+		End: start.Add(time.Duration(p.DurationMinutes) * time.Minute),
+		Message: p.Message,
+		Status: InterviewStatusPending,
+		CreatedAt: now,
+	}, nil
+}
+
+// ICS renders the confirmed interview as an iCalendar invite for both participants.
+func (i *InterviewRequest) ICS(recruiterEmail, engineerEmail string, now time.Time) []byte {
+	summary := "Interview"
+	switch {
+	case i.Company != "" && i.EngineerName != "":
+		summary = fmt.Sprintf("Interview: %s / %s", i.Company, i.EngineerName)
+	case i.Company != "":
+		summary = "Interview: " + i.Company
+	case i.EngineerName != "":
+		summary = "Interview with " + i.EngineerName
+	}
+
+	engineerAttendee := "ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:" + engineerEmail
+	if i.EngineerName != "" {
+		engineerAttendee = "ATTENDEE;CN=" + icsParam(i.EngineerName) + ";ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:" + engineerEmail
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Angular Talents//Interviews//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:REQUEST",
+		"BEGIN:VEVENT",
+		"UID:" + i.ID.String() + "@angulartalents.com",
+		"DTSTAMP:" + icsTime(now),
+		"DTSTART:" + icsTime(i.Start),
+		"DTEND:" + icsTime(i.End),
+		"SUMMARY:" + icsEscape(summary),
+		"DESCRIPTION:" + icsEscape(i.Message),
+		"ORGANIZER;CN=" + icsParam(i.RecruiterName) + ":mailto:" + recruiterEmail,
+		"ATTENDEE;CN=" + icsParam(i.RecruiterName) + ";ROLE=CHAIR;PARTSTAT=ACCEPTED:mailto:" + recruiterEmail,
+		engineerAttendee,
+		"STATUS:CONFIRMED",
+		"SEQUENCE:0",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(icsFold(line))
+		b.WriteString("\r\n")
+	}
+
+	return []byte(b.String())
+}
+
+func icsTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func icsEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+func icsParam(value string) string {
+	return `"` + strings.NewReplacer(`"`, "'", "\r", "", "\n", " ").Replace(value) + `"`
+}
+
+// icsFold splits content lines longer than 75 octets as RFC 5545 requires, without cutting
+// through a multi-byte character.
+func icsFold(line string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewInterviewRequestEngineerName(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	recruiter := &Recruiter{ID: uuid.New(), UserID: uuid.New(), Firstname: "Rita", Lastname: "Recruiter", Company: "Acme"}
+	member := &Viewer{UserID: recruiter.UserID, IsMember: true}
+
+	tests := []struct {
+		name        string
+		visibility  map[string]string
+		wantName    string
+		wantSummary string
+	}{
+		{"name shown to members", nil, "Ada Lovelace", "SUMMARY:Interview: Acme / Ada Lovelace"},
+		{"last name kept to contacts", map[string]string{"Lastname": VisibilityContacts}, "Ada", "SUMMARY:Interview: Acme / Ada"},
+		{"name kept to the owner", map[string]string{"Firstname": VisibilityOwner, "Lastname": VisibilityOwner}, "", "SUMMARY:Interview: Acme\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engineer := &Engineer{ID: uuid.New(), UserID: uuid.New(), Firstname: "Ada", Lastname: "Lovelace", FieldVisibility: tt.visibility}
+			payload := &CreateInterviewRequestPayload{Start: now.Add(24 * time.Hour), DurationMinutes: 30}
+
+			interview, err := payload.NewInterviewRequest(recruiter, engineer, member, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if interview.EngineerName != tt.wantName {
+				t.Errorf("EngineerName = %q, want %q", interview.EngineerName, tt.wantName)
+			}
+
+			invite := string(interview.ICS("rita@acme.example", "ada@example.com", now))
+			if !strings.Contains(invite, tt.wantSummary) {
+				t.Errorf("ICS() = %q, want it to contain %q", invite, tt.wantSummary)
+			}
+			if tt.wantName == "" && strings.Contains(invite, "Lovelace") {
+				t.Errorf("ICS() = %q, leaks the concealed name", invite)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"angular-talents-backend/db"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	JobStatusDraft     = "draft"
+	JobStatusPublished = "published"
+	JobStatusClosed    = "closed"
+)
+
+// jobTransitions lists the statuses a job may move to from each status. Closed jobs can be
+// published again.
+var jobTransitions = map[string][]string{
+	JobStatusDraft:     {JobStatusPublished},
+	JobStatusPublished: {JobStatusClosed},
+	JobStatusClosed:    {JobStatusPublished},
+}
+
+type Job struct {
+	ID uuid.UUID				`bson:"_id,required"`
+	RecruiterID uuid.UUID		`bson:"recruiter_id,required"`
+	Company string				`bson:"company,omitempty"`
+	Title string				`bson:"title,required"`
+	Description string			`bson:"description,required"`
+	RoleType []string			`bson:"role_type,required"`
+	RoleLevel []string			`bson:"role_level,required"`
+	City string					`bson:"city,omitempty"`
+	Country string				`bson:"country,omitempty"`
+	CountryCode string			`bson:"country_code,omitempty"`
+	Remote string				`bson:"remote,required"`
+	SalaryMin int				`bson:"salary_min,omitempty"`
+	SalaryMax int				`bson:"salary_max,omitempty"`
+	SalaryCurrency string		`bson:"salary_currency,omitempty"`
+	SalaryPeriod string			`bson:"salary_period,omitempty"`
+	Skills []string				`bson:"skills,omitempty"`
+	Status string				`bson:"status,required"`
+	CreatedAt time.Time			`bson:"created_at,required"`
+	UpdatedAt time.Time			`bson:"updated_at,required"`
+	PublishedAt *time.Time		`bson:"published_at,omitempty"`
+	ClosedAt *time.Time			`bson:"closed_at,omitempty"`
+}
+
+type CreateJobPayload struct {
+	Title string			`json:"title" validate:"required,max=120"`
+	Description string		`json:"description" validate:"required,max=10000"`
+	RoleType []string		`json:"roleType" validate:"required,dive,oneof=contract_part_time contract_full_time employee_part_time employee_full_time"`
+	RoleLevel []string		`json:"roleLevel" validate:"required,dive,oneof=junior mid_level senior principal_staff c_level"`
+	City string				`json:"city,omitempty"`
+	Country string			`json:"country,omitempty" validate:"required_unless=Remote remote"`
+	Remote string			`json:"remote" validate:"required,oneof=remote hybrid onsite"`
+	SalaryMin int			`json:"salaryMin,omitempty" validate:"omitempty,min=0"`
+	SalaryMax int			`json:"salaryMax,omitempty" validate:"omitempty,gtefield=SalaryMin"`
+	SalaryCurrency string	`json:"salaryCurrency,omitempty" validate:"required_with=SalaryMin SalaryMax,omitempty,iso4217"`
+	SalaryPeriod string		`json:"salaryPeriod,omitempty" validate:"required_with=SalaryMin SalaryMax,omitempty,oneof=hour day month year"`
+	Skills []string			`json:"skills,omitempty" validate:"omitempty,max=30,dive,required,max=50"`
+}
+
+type UpdateJobPayload struct {
+	Title string			`bson:"title,omitempty" json:"title" validate:"omitempty,max=120"`
+	Description string		`bson:"description,omitempty" json:"description" validate:"omitempty,max=10000"`
+	RoleType []string		`bson:"role_type,omitempty" json:"roleType" validate:"omitempty,dive,oneof=contract_part_time contract_full_time employee_part_time employee_full_time"`
+	RoleLevel []string		`bson:"role_level,omitempty" json:"roleLevel" validate:"omitempty,dive,oneof=junior mid_level senior principal_staff c_level"`
+	City string				`bson:"city,omitempty" json:"city,omitempty"`
+	Country string			`bson:"country,omitempty" json:"country,omitempty"`
+	CountryCode string		`bson:"country_code,omitempty" json:"-"`
+	Remote string			`bson:"remote,omitempty" json:"remote" validate:"omitempty,oneof=remote hybrid onsite"`
+	SalaryMin int			`bson:"salary_min,omitempty" json:"salaryMin,omitempty" validate:"omitempty,min=0"`
+	SalaryMax int			`bson:"salary_max,omitempty" json:"salaryMax,omitempty" validate:"omitempty,min=0"`
+	SalaryCurrency string	`bson:"salary_currency,omitempty" json:"salaryCurrency,omitempty" validate:"omitempty,iso4217"`
+	SalaryPeriod string		`bson:"salary_period,omitempty" json:"salaryPeriod,omitempty" validate:"omitempty,oneof=hour day month year"`
+	Skills []string			`bson:"skills,omitempty" json:"skills,omitempty" validate:"omitempty,max=30,dive,required,max=50"`
+	UpdatedAt time.Time		`bson:"updated_at,omitempty" json:"-"`
+}
+
+type ListJobsFilter struct {
+	RoleType string
+	RoleLevel string
+	Country string
+	Remote string
+	Skill string
+	MinSalary int
+	SalaryCurrency string
+	SalaryPeriod string
+	Query string
+}
+
+type ListJobsParams struct {
+	Pagination *Pagination
+	Filter *ListJobsFilter
+}
+
+func (p *CreateJobPayload) NewJob(recruiter *Recruiter) *Job {
+	now := time.Now()
+
+	return &Job{
+		ID: uuid.New(),
+		RecruiterID: recruiter.ID,
+		Company: recruiter.Company,
+		Title: p.Title,
+		Description: p.Description,
+		RoleType: p.RoleType,
+		RoleLevel: p.RoleLevel,
+		City: p.City,
+		Country: p.Country,
+		CountryCode: DefaultCityDataset().CountryCode(p.Country),
+		Remote: p.Remote,
+		SalaryMin: p.SalaryMin,
+		SalaryMax: p.SalaryMax,
+		SalaryCurrency: p.SalaryCurrency,
+		SalaryPeriod: p.SalaryPeriod,
+		Skills: normalizeSkills(p.Skills),
+		Status: JobStatusDraft,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// CanTransition tells whether the job may move to the given status.
+func (j *Job) CanTransition(status string) bool {
+	for _, allowed := range jobTransitions[j.Status] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// SalaryFilter matches the jobs paying at least MinSalary in the filter's currency and period:
+// those whose range reaches it, and those giving only a minimum that does.
+func (f *ListJobsFilter) SalaryFilter() bson.M {
+	return bson.M{
+		"salary_currency": f.SalaryCurrency,
+		"salary_period": f.SalaryPeriod,
+		"$or": bson.A{
+			bson.M{"salary_max": bson.M{"$gte": f.MinSalary}},
+			bson.M{"salary_max": bson.M{"$exists": false}, "salary_min": bson.M{"$gte": f.MinSalary}},
+		},
+	}
+}
+
+// Validate checks the payload, the job once merged with the current one against the rules jobs are
+// created with, and that the recruiter owns the job.
+func (u *UpdateJobPayload) Validate(ctx context.Context, recruiterID uuid.UUID, jobID string) error {
+	v := validator.New()
+	err := v.Struct(u)
+	if err != nil {
+		return err
+	}
+
+	parsedJobID, err := uuid.Parse(jobID)
+	if err != nil {
+		return err
+	}
+
+	job, err := u.checkRecruiterOwnsJob(ctx, recruiterID, parsedJobID)
+	if err != nil {
+		return err
+	}
+
+	if job == nil {
+		return errors.New("can't update job belonging to other recruiter")
+	}
+
+	salaryMin, salaryMax := job.SalaryMin, job.SalaryMax
+	if u.SalaryMin != 0 {
+		salaryMin = u.SalaryMin
+	}
+	if u.SalaryMax != 0 {
+		salaryMax = u.SalaryMax
+	}
+	if salaryMax != 0 && salaryMax < salaryMin {
+		return fmt.Errorf("salary max %d is below salary min %d", salaryMax, salaryMin)
+	}
+
+	salaryCurrency, salaryPeriod := job.SalaryCurrency, job.SalaryPeriod
+	if u.SalaryCurrency != "" {
+		salaryCurrency = u.SalaryCurrency
+	}
+	if u.SalaryPeriod != "" {
+		salaryPeriod = u.SalaryPeriod
+	}
+	if (salaryMin != 0 || salaryMax != 0) && (salaryCurrency == "" || salaryPeriod == "") {
+		return errors.New("salary currency and period are required with a salary")
+	}
+
+	remote, country := job.Remote, job.Country
+	if u.Remote != "" {
+		remote = u.Remote
+	}
+	if u.Country != "" {
+		country = u.Country
+	}
+	if remote != "remote" && country == "" {
+		return fmt.Errorf("country is required for %s jobs", remote)
+	}
+
+	if u.Country != "" {
+		u.CountryCode = DefaultCityDataset().CountryCode(u.Country)
+	}
+	u.Skills = normalizeSkills(u.Skills)
+
+	return nil
+}
+
+func (u *UpdateJobPayload) checkRecruiterOwnsJob(ctx context.Context, recruiterID, jobID uuid.UUID) (*Job, error) {
+	jobCol := db.Database.Collection("jobs")
+
+	var job Job
+
+	filter := bson.D{{Key: "_id", Value: jobID}}
+	err := jobCol.FindOne(ctx, filter).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("Job not found")
+		}
+		return nil, err
+	}
+
+	if job.RecruiterID != recruiterID {
+		return nil, nil
+	}
+
+	return &job, nil
+}
+
+func NewListJobsParams(q url.Values) (*ListJobsParams, error) {
+	pagination, err := NewPagination(q, 20, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &ListJobsParams{
+		Pagination: pagination,
+		Filter: &ListJobsFilter{
+			RoleType: q.Get("roleType"),
+			RoleLevel: q.Get("roleLevel"),
+			Country: q.Get("country"),
+			Remote: q.Get("remote"),
+			Skill: strings.ToLower(strings.TrimSpace(q.Get("skill"))),
+			Query: strings.TrimSpace(q.Get("q")),
+		},
+	}
+
+	// salaries are only comparable in the same currency and over the same period
+	if q.Get("minSalary") != "" {
+		minSalary, err := strconv.Atoi(q.Get("minSalary"))
+		if err != nil {
+			return nil, err
+		}
+
+		currency := strings.ToUpper(strings.TrimSpace(q.Get("salaryCurrency")))
+		period := strings.TrimSpace(q.Get("salaryPeriod"))
+		v := validator.New()
+		if v.Var(currency, "required,iso4217") != nil || v.Var(period, "required,oneof=hour day month year") != nil {
+			return nil, errors.New("minSalary requires a salaryCurrency and a salaryPeriod of hour, day, month or year")
+		}
+
+		params.Filter.MinSalary = minSalary
+		params.Filter.SalaryCurrency = currency
+		params.Filter.SalaryPeriod = period
+	}
+
+	return params, nil
+}
+
+func normalizeSkills(skills []string) []string {
+	if skills == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, skill := range skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if skill == "" || seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		normalized = append(normalized, skill)
+	}
+
+	return normalized
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"net/url"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestListJobsSalaryFilter(t *testing.T) {
+	params, err := NewListJobsParams(url.Values{"minSalary": {"60000"}, "salaryCurrency": {"eur"}, "salaryPeriod": {"year"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := func(salaryMin, salaryMax int, currency, period string) bson.M {
+		doc := bson.M{"salary_currency": currency, "salary_period": period}
+		if salaryMin != 0 {
+			doc["salary_min"] = salaryMin
+		}
+		if salaryMax != 0 {
+			doc["salary_max"] = salaryMax
+		}
+		return doc
+	}
+
+	tests := []struct {
+		name string
+		job  bson.M
+		want bool
+	}{
+		{"range reaching the minimum", job(50000, 70000, "EUR", "year"), true},
+		{"range below the minimum", job(40000, 55000, "EUR", "year"), false},
+		{"only a minimum above it", job(65000, 0, "EUR", "year"), true},
+		{"only a minimum below it", job(50000, 0, "EUR", "year"), false},
+		{"only a maximum above it", job(0, 80000, "EUR", "year"), true},
+		{"other currency", job(50000, 70000, "USD", "year"), false},
+		{"other period", job(50000, 70000, "EUR", "month"), false},
+		{"no salary", bson.M{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := params.Filter.SalaryFilter()
+			if got := matchesFilter(tt.job, filter); got != tt.want {
+				t.Errorf("filter %v matched = %v, want %v", filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewListJobsParamsRequiresSalaryUnit(t *testing.T) {
+	tests := []url.Values{
+		{"minSalary": {"60000"}},
+		{"minSalary": {"60000"}, "salaryCurrency": {"EUR"}},
+		{"minSalary": {"60000"}, "salaryCurrency": {"EUR"}, "salaryPeriod": {"week"}},
+		{"minSalary": {"60000"}, "salaryCurrency": {"euros"}, "salaryPeriod": {"year"}},
+	}
+
+	for _, q := range tests {
+		t.Run(q.Encode(), func(t *testing.T) {
+			if _, err := NewListJobsParams(q); err == nil {
+				t.Errorf("NewListJobsParams(%v) error = nil, want an error", q)
+			}
+		})
+	}
+}
@@ -0,0 +1,493 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const (
+	jsonResumeSchema  = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+	jsonResumeVersion = "v1.0.0"
+	technologiesLabel = "Technologies: "
+	coursesLabel      = "Courses: "
+)
+
+// JSONResume is the subset of the JSON Resume schema (https://jsonresume.org/schema) that has a
+// counterpart on engineer profiles.
+type JSONResume struct {
+	Schema string						`json:"$schema,omitempty"`
+	Basics JSONResumeBasics				`json:"basics"`
+	Work []JSONResumeWork				`json:"work,omitempty"`
+	Education []JSONResumeEducation		`json:"education,omitempty"`
+	Projects []JSONResumeProject		`json:"projects,omitempty"`
+	Meta *JSONResumeMeta				`json:"meta,omitempty"`
+}
+
+type JSONResumeBasics struct {
+	Name string							`json:"name,omitempty"`
+	Label string						`json:"label,omitempty"`
+	Image string						`json:"image,omitempty"`
+	Email string						`json:"email,omitempty"`
+	URL string							`json:"url,omitempty"`
+	Summary string						`json:"summary,omitempty"`
+	Location *JSONResumeLocation		`json:"location,omitempty"`
+	Profiles []JSONResumeProfile		`json:"profiles,omitempty"`
+}
+
+type JSONResumeLocation struct {
+	City string							`json:"city,omitempty"`
+	Region string						`json:"region,omitempty"`
+	CountryCode string					`json:"countryCode,omitempty"`
+}
+
+type JSONResumeProfile struct {
+	Network string						`json:"network,omitempty"`
+	Username string						`json:"username,omitempty"`
+	URL string							`json:"url,omitempty"`
+}
+
+type JSONResumeWork struct {
+	Name string							`json:"name,omitempty"`
+	Position string						`json:"position,omitempty"`
+	StartDate string					`json:"startDate,omitempty"`
+	EndDate string						`json:"endDate,omitempty"`
+	Summary string						`json:"summary,omitempty"`
+	Highlights []string					`json:"highlights,omitempty"`
+}
+
+type JSONResumeEducation struct {
+	Institution string					`json:"institution,omitempty"`
+	Area string							`json:"area,omitempty"`
+	StudyType string					`json:"studyType,omitempty"`
+	StartDate string					`json:"startDate,omitempty"`
+	EndDate string						`json:"endDate,omitempty"`
+	Courses []string					`json:"courses,omitempty"`
+}
+
+type JSONResumeProject struct {
+	Name string							`json:"name,omitempty"`
+	Description string					`json:"description,omitempty"`
+	Highlights []string					`json:"highlights,omitempty"`
+	Keywords []string					`json:"keywords,omitempty"`
+	URL string							`json:"url,omitempty"`
+}
+
+type JSONResumeMeta struct {
+	Canonical string					`json:"canonical,omitempty"`
+	Version string						`json:"version,omitempty"`
+	LastModified string					`json:"lastModified,omitempty"`
+}
+
+// ImportJSONResumePayload carries the resume as raw JSON: resumes have many sections and
+// extensions that profiles don't know about, and those must not fail the import. Search status
+// and roles have no equivalent in JSON Resume, so they are only needed to create a profile.
+type ImportJSONResumePayload struct {
+	Resume json.RawMessage	`json:"resume" validate:"required"`
+	DryRun bool				`json:"dryRun,omitempty"`
+	SearchStatus string		`json:"searchStatus,omitempty" validate:"omitempty,oneof=actively_looking open not_interested invisible"`
+	RoleType []string		`json:"roleType,omitempty" validate:"omitempty,dive,oneof=contract_part_time contract_full_time employee_part_time employee_full_time"`
+	RoleLevel []string		`json:"roleLevel,omitempty" validate:"omitempty,dive,oneof=junior mid_level senior principal_staff c_level"`
+}
+
+// ResumeImport is what a resume contributes to a profile. Values that don't pass the profile's
+// validation are left out, with a warning explaining why.
+type ResumeImport struct {
+	Fields *UpdateEngineerPayload
+	Github string
+	LinkedIn string
+	Warnings []string
+}
+
+// ProfileChange describes one field an import would change, keyed by its payload name.
+type ProfileChange struct {
+	Field string			`json:"field"`
+	From interface{}		`json:"from"`
+	To interface{}			`json:"to"`
+}
+
+var importedSections = map[string]bool{"$schema": true, "basics": true, "work": true, "education": true, "projects": true, "meta": true}
+
+func ParseJSONResume(raw []byte) (*JSONResume, []string, error) {
+	var resume JSONResume
+	err := json.Unmarshal(raw, &resume)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resume is not a valid JSON Resume document: %s", err.Error())
+	}
+
+	var sections map[string]json.RawMessage
+	err = json.Unmarshal(raw, &sections)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	skipped := []string{}
+	for name, value := range sections {
+		if !importedSections[name] && string(value) != "[]" && string(value) != "null" {
+			skipped = append(skipped, name)
+		}
+	}
+	sort.Strings(skipped)
+
+	warnings := []string{}
+	if len(skipped) > 0 {
+		warnings = append(warnings, fmt.Sprintf("sections %s have no equivalent on profiles and were skipped", strings.Join(skipped, ", ")))
+	}
+
+	return &resume, warnings, nil
+}
+
+// Import maps the resume onto profile fields. Sections present in the resume replace the
+// profile's ones entirely.
+func (r *JSONResume) Import(warnings []string, now time.Time) *ResumeImport {
+	result := &ResumeImport{Fields: &UpdateEngineerPayload{}, Warnings: warnings}
+	fields := result.Fields
+	basics := r.Basics
+
+	firstName, lastName := splitName(basics.Name)
+	result.set("FirstName", firstName, func(v string) { fields.FirstName = v })
+	result.set("LastName", lastName, func(v string) { fields.LastName = v })
+	result.set("Tagline", basics.Label, func(v string) { fields.Tagline = v })
+	result.set("Bio", basics.Summary, func(v string) { fields.Bio = v })
+	result.set("Avatar", basics.Image, func(v string) { fields.Avatar = v })
+	result.set("Website", basics.URL, func(v string) { fields.Website = v })
+
+	if basics.Location != nil {
+		country := DefaultCityDataset().CountryName(basics.Location.CountryCode)
+		if country == "" {
+			country = basics.Location.CountryCode
+		}
+
+		result.set("City", basics.Location.City, func(v string) { fields.City = v })
+		result.set("State", basics.Location.Region, func(v string) { fields.State = v })
+		result.set("Country", country, func(v string) { fields.Country = v })
+	}
+
+	for _, profile := range basics.Profiles {
+		profileURL := profile.URL
+		switch strings.ToLower(strings.ReplaceAll(profile.Network, " ", "")) {
+		case "github":
+			result.Github = profileOrBuiltURL(profileURL, "https://github.com/", profile.Username)
+		case "linkedin":
+			result.LinkedIn = profileOrBuiltURL(profileURL, "https://www.linkedin.com/in/", profile.Username)
+		case "twitter", "x":
+			result.set("Twitter", profileOrBuiltURL(profileURL, "https://twitter.com/", profile.Username), func(v string) { fields.Twitter = v })
+		case "stackoverflow":
+			result.set("StackOverflow", profileURL, func(v string) { fields.StackOverflow = v })
+		}
+	}
+
+	for i, work := range r.Work {
+		description, technologies := splitHighlights(work.Summary, work.Highlights)
+		payload := &ExperiencePayload{
+			Company: work.Name,
+			Title: work.Position,
+			StartDate: resumeMonth(work.StartDate),
+			EndDate: resumeMonth(work.EndDate),
+			Current: work.EndDate == "",
+			Description: description,
+			Technologies: technologies,
+		}
+
+		if err := payload.Validate(now); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("work entry %d skipped: %s", i+1, err.Error()))
+			continue
+		}
+		fields.Experience = append(fields.Experience, *payload.Entry(uuid.New()).(*ExperienceEntry))
+	}
+
+	for i, education := range r.Education {
+		description := ""
+		if len(education.Courses) > 0 {
+			description = coursesLabel + strings.Join(education.Courses, ", ")
+		}
+
+		payload := &EducationPayload{
+			School: education.Institution,
+			Degree: education.StudyType,
+			FieldOfStudy: education.Area,
+			StartDate: resumeMonth(education.StartDate),
+			EndDate: resumeMonth(education.EndDate),
+			Description: description,
+		}
+
+		if err := payload.Validate(now); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("education entry %d skipped: %s", i+1, err.Error()))
+			continue
+		}
+		fields.Education = append(fields.Education, *payload.Entry(uuid.New()).(*EducationEntry))
+	}
+
+	for i, project := range r.Projects {
+		description, _ := splitHighlights(project.Description, project.Highlights)
+		payload := &PortfolioProjectPayload{
+			Name: project.Name,
+			Description: description,
+			Technologies: project.Keywords,
+		}
+		if isRepositoryURL(project.URL) {
+			payload.Repo = project.URL
+		} else {
+			payload.URL = project.URL
+		}
+
+		if err := payload.Validate(now); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("project %d skipped: %s", i+1, err.Error()))
+			continue
+		}
+		fields.Projects = append(fields.Projects, *payload.Entry(uuid.New()).(*PortfolioProject))
+	}
+
+	return result
+}
+
+// set keeps the value when it passes the field's validation on UpdateEngineerPayload.
+func (i *ResumeImport) set(field, value string, assign func(string)) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+
+	assign(value)
+	err := validator.New().StructPartial(i.Fields, field)
+	if err != nil {
+		assign("")
+		i.Warnings = append(i.Warnings, fmt.Sprintf("%s %q skipped: it doesn't pass the profile's validation", field, value))
+	}
+}
+
+// Changes lists what applying the import would change on the current profile, which is nil
+// when the import would create it.
+func (i *ResumeImport) Changes(current *Engineer) []ProfileChange {
+	if current == nil {
+		current = &Engineer{}
+	}
+	fields := i.Fields
+
+	changes := []ProfileChange{}
+	// empty values are not imported, so they change nothing
+	compare := func(field string, from, to interface{}) {
+		if reflect.ValueOf(to).Len() == 0 || reflect.DeepEqual(from, to) {
+			return
+		}
+		changes = append(changes, ProfileChange{Field: field, From: from, To: to})
+	}
+
+	// imported entries get fresh IDs, which must not count as changes
+	compareEntries := func(field string, from, to interface{}) {
+		if reflect.ValueOf(to).Len() == 0 || reflect.DeepEqual(withoutEntryIDs(from), withoutEntryIDs(to)) {
+			return
+		}
+		changes = append(changes, ProfileChange{Field: field, From: from, To: to})
+	}
+
+	compare("firstName", current.Firstname, fields.FirstName)
+	compare("lastName", current.Lastname, fields.LastName)
+	compare("tagline", current.Tagline, fields.Tagline)
+	compare("bio", current.Bio, fields.Bio)
+	compare("avatar", current.Avatar, fields.Avatar)
+	compare("city", current.City, fields.City)
+	compare("state", current.State, fields.State)
+	compare("country", current.Country, fields.Country)
+	compare("website", current.Website, fields.Website)
+	compare("twitter", current.Twitter, fields.Twitter)
+	compare("stackOverflow", current.StackOverflow, fields.StackOverflow)
+	if current.ID == uuid.Nil {
+		compare("github", current.Github, i.Github)
+		compare("linkedIn", current.LinkedIn, i.LinkedIn)
+	}
+	compareEntries("experience", current.Experience, fields.Experience)
+	compareEntries("education", current.Education, fields.Education)
+	compareEntries("projects", current.Projects, fields.Projects)
+
+	return changes
+}
+
+// WarnFixedLinks tells when the resume has other GitHub or LinkedIn profiles than the existing
+// profile, as an import only sets those links when it creates the profile.
+func (i *ResumeImport) WarnFixedLinks(current *Engineer) {
+	if i.Github != "" && i.Github != current.Github {
+		i.Warnings = append(i.Warnings, "the GitHub profile can't be changed by an import")
+	}
+	if i.LinkedIn != "" && i.LinkedIn != current.LinkedIn {
+		i.Warnings = append(i.Warnings, "the LinkedIn profile can't be changed by an import")
+	}
+}
+
+// NewEngineerPayload completes the import with what JSON Resume can't provide, so that it can
+// be validated and inserted like a profile created through POST /engineers.
+func (i *ResumeImport) NewEngineerPayload(p *ImportJSONResumePayload) *CreateEngineerPayload {
+	fields := i.Fields
+
+	return &CreateEngineerPayload{
+		FirstName: fields.FirstName,
+		LastName: fields.LastName,
+		Tagline: fields.Tagline,
+		City: fields.City,
+		State: fields.State,
+		Country: fields.Country,
+		Avatar: fields.Avatar,
+		Bio: fields.Bio,
+		SearchStatus: p.SearchStatus,
+		RoleType: p.RoleType,
+		RoleLevel: p.RoleLevel,
+		Website: fields.Website,
+		Github: i.Github,
+		Twitter: fields.Twitter,
+		LinkedIn: i.LinkedIn,
+		StackOverflow: fields.StackOverflow,
+	}
+}
+
+// NewJSONResume exports the profile. Technologies, which JSON Resume has no field for on work
+// entries, are written as a highlight that Import recognizes.
+func NewJSONResume(e *Engineer, email string) *JSONResume {
+	resume := &JSONResume{
+		Schema: jsonResumeSchema,
+		Basics: JSONResumeBasics{
+			Name: strings.TrimSpace(e.Firstname + " " + e.Lastname),
+			Label: e.Tagline,
+			Image: e.Avatar,
+			Email: email,
+			URL: e.Website,
+			Summary: e.Bio,
+			Location: &JSONResumeLocation{City: e.City, Region: e.State, CountryCode: e.CountryCode},
+			Profiles: []JSONResumeProfile{},
+		},
+		Work: []JSONResumeWork{},
+		Education: []JSONResumeEducation{},
+		Projects: []JSONResumeProject{},
+		Meta: &JSONResumeMeta{
+			Canonical: EngineerPublicURL(e),
+			Version: jsonResumeVersion,
+			LastModified: e.UpdatedAt.UTC().Format(time.RFC3339),
+		},
+	}
+
+	profiles := [][2]string{{"GitHub", e.Github}, {"LinkedIn", e.LinkedIn}, {"Twitter", e.Twitter}, {"Stack Overflow", e.StackOverflow}}
+	for _, profile := range profiles {
+		if profile[1] != "" {
+			resume.Basics.Profiles = append(resume.Basics.Profiles, JSONResumeProfile{Network: profile[0], Username: profileUsername(profile[1]), URL: profile[1]})
+		}
+	}
+
+	for _, entry := range e.Experience {
+		work := JSONResumeWork{Name: entry.Company, Position: entry.Title, StartDate: entry.StartDate, Summary: entry.Description}
+		if !entry.Current {
+			work.EndDate = entry.EndDate
+		}
+		if len(entry.Technologies) > 0 {
+			work.Highlights = []string{technologiesLabel + strings.Join(entry.Technologies, ", ")}
+		}
+		resume.Work = append(resume.Work, work)
+	}
+
+	for _, entry := range e.Education {
+		education := JSONResumeEducation{Institution: entry.School, Area: entry.FieldOfStudy, StudyType: entry.Degree, StartDate: entry.StartDate, EndDate: entry.EndDate}
+		if strings.HasPrefix(entry.Description, coursesLabel) {
+			education.Courses = strings.Split(strings.TrimPrefix(entry.Description, coursesLabel), ", ")
+		}
+		resume.Education = append(resume.Education, education)
+	}
+
+	for _, project := range e.Projects {
+		projectURL := project.URL
+		if projectURL == "" {
+			projectURL = project.Repo
+		}
+		resume.Projects = append(resume.Projects, JSONResumeProject{Name: project.Name, Description: project.Description, Keywords: project.Technologies, URL: projectURL})
+	}
+
+	return resume
+}
+
+// splitName takes the first word as the first name, as JSON Resume only has a full name.
+func splitName(name string) (string, string) {
+	parts := strings.Fields(name)
+	if len(parts) == 0 {
+		return "", ""
+	}
+	return parts[0], strings.Join(parts[1:], " ")
+}
+
+// resumeMonth reduces an ISO 8601 date, which JSON Resume allows down to the year, to the month
+// profiles store.
+func resumeMonth(value string) string {
+	value = strings.TrimSpace(value)
+	switch {
+	case len(value) >= len(monthLayout) && value[4] == '-':
+		return value[:len(monthLayout)]
+	case len(value) == 4:
+		return value + "-01"
+	default:
+		return value
+	}
+}
+
+// splitHighlights joins the highlights to the summary as bullet points, except for the
+// technologies highlight written by NewJSONResume.
+func splitHighlights(summary string, highlights []string) (string, []string) {
+	lines := []string{}
+	if strings.TrimSpace(summary) != "" {
+		lines = append(lines, strings.TrimSpace(summary))
+	}
+
+	var technologies []string
+	for _, highlight := range highlights {
+		if strings.HasPrefix(highlight, technologiesLabel) {
+			for _, technology := range strings.Split(strings.TrimPrefix(highlight, technologiesLabel), ",") {
+				if strings.TrimSpace(technology) != "" {
+					technologies = append(technologies, strings.TrimSpace(technology))
+				}
+			}
+			continue
+		}
+		lines = append(lines, "• "+strings.TrimSpace(highlight))
+	}
+
+	return strings.Join(lines, "\n"), technologies
+}
+
+func profileOrBuiltURL(profileURL, base, username string) string {
+	if profileURL != "" || username == "" {
+		return profileURL
+	}
+	return base + url.PathEscape(strings.TrimPrefix(username, "@"))
+}
+
+func profileUsername(profileURL string) string {
+	parsed, err := url.Parse(profileURL)
+	if err != nil {
+		return ""
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	return segments[len(segments)-1]
+}
+
+func isRepositoryURL(value string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+
+	host := strings.TrimPrefix(parsed.Host, "www.")
+	return host == "github.com" || host == "gitlab.com" || host == "bitbucket.org"
+}
+
+func withoutEntryIDs(entries interface{}) interface{} {
+	value := reflect.ValueOf(entries)
+	stripped := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+	for i := 0; i < value.Len(); i++ {
+		stripped.Index(i).Set(value.Index(i))
+		stripped.Index(i).FieldByName("ID").Set(reflect.ValueOf(uuid.Nil))
+	}
+	return stripped.Interface()
+}
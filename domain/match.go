@@ -0,0 +1,505 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	MatchFactorRoleType     = "roleType"
+	MatchFactorRoleLevel    = "roleLevel"
+	MatchFactorLocation     = "location"
+	MatchFactorSearchStatus = "searchStatus"
+	MatchFactorText         = "text"
+)
+
+// MaxMatchCandidates bounds how many engineers passing the must-haves are scored. When more pass
+// them, the ones kept are those with the best PrescoreExpression.
+const MaxMatchCandidates = 500
+
+// matchNearbyKm is the distance under which an engineer counts as being in a requested city.
+const matchNearbyKm = 100.0
+
+var defaultMatchWeights = map[string]float64{
+	MatchFactorRoleType:     25,
+	MatchFactorRoleLevel:    25,
+	MatchFactorLocation:     20,
+	MatchFactorSearchStatus: 15,
+	MatchFactorText:         15,
+}
+
+var roleLevelOrder = map[string]int{
+	"junior":          0,
+	"mid_level":       1,
+	"senior":          2,
+	"principal_staff": 3,
+	"c_level":         4,
+}
+
+// matchRegions maps region names to the IANA timezone areas of the engineers living there.
+var matchRegions = map[string][]string{
+	"europe":        {"Europe/"},
+	"americas":      {"America/"},
+	"north america": {"America/"},
+	"south america": {"America/"},
+	"latin america": {"America/"},
+	"asia":          {"Asia/"},
+	"africa":        {"Africa/"},
+	"oceania":       {"Australia/", "Pacific/"},
+	"australia":     {"Australia/"},
+}
+
+// MatchSpec describes a role. Every criterion is a nice-to-have that contributes to the score
+// unless it is listed in MustHave, in which case engineers missing it are not returned at all.
+type MatchSpec struct {
+	RoleTypes []string				`json:"roleTypes,omitempty" validate:"omitempty,dive,oneof=contract_part_time contract_full_time employee_part_time employee_full_time"`
+	RoleLevels []string				`json:"roleLevels,omitempty" validate:"omitempty,dive,oneof=junior mid_level senior principal_staff c_level"`
+	Locations []string				`json:"locations,omitempty" validate:"omitempty,max=20,dive,required,max=100"`
+	Keywords []string				`json:"keywords,omitempty" validate:"omitempty,max=20,dive,required,max=50"`
+	MustHave []string				`json:"mustHave,omitempty" validate:"omitempty,dive,oneof=roleType roleLevel location searchStatus text"`
+	Weights map[string]float64		`json:"weights,omitempty" validate:"omitempty,dive,keys,oneof=roleType roleLevel location searchStatus text,endkeys,min=0,max=100"`
+	Limit int						`json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+type MatchFactor struct {
+	Name string
+	Weight float64
+	Score float64
+	Explanation string
+}
+
+type MatchResult struct {
+	Engineer map[string]interface{}
+	Score float64
+	Factors []MatchFactor
+}
+
+type matchLocation struct {
+	Name string
+	Region []string
+	CountryCode string
+	Point *GeoPoint
+}
+
+// Matcher scores engineers against a spec whose locations were resolved once up front. It only
+// looks at the fields of an engineer that the viewer may see.
+type Matcher struct {
+	spec      *MatchSpec
+	viewer    *Viewer
+	locations []*matchLocation
+	weights   map[string]float64
+	keywords  []string
+}
+
+type ScoredEngineer struct {
+	Engineer *Engineer
+	Score float64
+	Factors []MatchFactor
+}
+
+func (s *MatchSpec) Validate() error {
+	v := validator.New()
+	err := v.Struct(s)
+	if err != nil {
+		return err
+	}
+
+	for _, factor := range s.MustHave {
+		if !s.hasCriterion(factor) {
+			return fmt.Errorf("must-have %s has no criterion", factor)
+		}
+	}
+
+	return nil
+}
+
+func (s *MatchSpec) hasCriterion(factor string) bool {
+	switch factor {
+	case MatchFactorRoleType:
+		return len(s.RoleTypes) > 0
+	case MatchFactorRoleLevel:
+		return len(s.RoleLevels) > 0
+	case MatchFactorLocation:
+		return len(s.Locations) > 0
+	case MatchFactorText:
+		return len(s.Keywords) > 0
+	default:
+		return true
+	}
+}
+
+func (s *MatchSpec) isMustHave(factor string) bool {
+	for _, mustHave := range s.MustHave {
+		if mustHave == factor {
+			return true
+		}
+	}
+	return false
+}
+
+func NewMatcher(ctx context.Context, spec *MatchSpec, viewer *Viewer) (*Matcher, error) {
+	m := &Matcher{spec: spec, viewer: viewer, weights: map[string]float64{}}
+
+	for factor, weight := range defaultMatchWeights {
+		if override, ok := spec.Weights[factor]; ok {
+			weight = override
+		}
+		m.weights[factor] = weight
+	}
+
+	for _, keyword := range spec.Keywords {
+		m.keywords = append(m.keywords, strings.ToLower(strings.TrimSpace(keyword)))
+	}
+
+	for _, name := range spec.Locations {
+		location, err := resolveMatchLocation(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		m.locations = append(m.locations, location)
+	}
+
+	return m, nil
+}
+
+// resolveMatchLocation accepts a region ("Europe"), a country or a city.
+func resolveMatchLocation(ctx context.Context, name string) (*matchLocation, error) {
+	location := &matchLocation{Name: name}
+
+	if region, ok := matchRegions[normalizePlace(name)]; ok {
+		location.Region = region
+		return location, nil
+	}
+
+	if countryCode := DefaultCityDataset().CountryCode(name); countryCode != "" {
+		location.CountryCode = countryCode
+		return location, nil
+	}
+
+	parts := strings.Split(name, ",")
+	query := GeocodeQuery{City: strings.TrimSpace(parts[0])}
+	if len(parts) > 1 {
+		query.Country = strings.TrimSpace(parts[len(parts)-1])
+	}
+
+	result, err := CurrentGeocoder().Geocode(ctx, query)
+	if err != nil || result.Location == nil {
+		return nil, fmt.Errorf("unknown location %q", name)
+	}
+
+	location.Point = result.Location
+	location.CountryCode = result.CountryCode
+
+	return location, nil
+}
+
+// MustHaveFilter returns the mongo clauses for the must-have criteria. Engineers only pass a
+// criterion on fields the viewer may see.
+func (m *Matcher) MustHaveFilter() bson.M {
+	clauses := bson.A{}
+	spec := m.spec
+
+	if spec.isMustHave(MatchFactorRoleType) {
+		clauses = append(clauses, m.visible("RoleType", bson.M{"role_type": bson.M{"$in": spec.RoleTypes}}))
+	}
+
+	if spec.isMustHave(MatchFactorRoleLevel) {
+		clauses = append(clauses, m.visible("RoleLevel", bson.M{"role_level": bson.M{"$in": spec.RoleLevels}}))
+	}
+
+	if spec.isMustHave(MatchFactorSearchStatus) {
+		clauses = append(clauses, m.visible("SearchStatus", bson.M{"search_status": bson.M{"$in": bson.A{SearchStatusActivelyLooking, SearchStatusOpen}}}))
+	}
+
+	if spec.isMustHave(MatchFactorLocation) {
+		alternatives := bson.A{}
+		for _, location := range m.locations {
+			for _, prefix := range location.Region {
+				alternatives = append(alternatives, m.visible("Timezone", bson.M{"timezone": bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}}))
+			}
+			if location.Point != nil {
+				alternatives = append(alternatives, m.visible("Location", bson.M{"location": bson.M{"$geoWithin": bson.M{"$centerSphere": bson.A{location.Point.Coordinates, matchNearbyKm / earthRadiusKm}}}}))
+			} else if location.CountryCode != "" {
+				alternatives = append(alternatives, m.visible("CountryCode", bson.M{"country_code": location.CountryCode}))
+			}
+		}
+		clauses = append(clauses, bson.M{"$or": alternatives})
+	}
+
+	if spec.isMustHave(MatchFactorText) {
+		alternatives := bson.A{}
+		for _, keyword := range m.keywords {
+			pattern := bson.M{"$regex": regexp.QuoteMeta(keyword), "$options": "i"}
+			alternatives = append(alternatives, m.visible("Tagline", bson.M{"tagline": pattern}), m.visible("Bio", bson.M{"bio": pattern}))
+		}
+		clauses = append(clauses, bson.M{"$or": alternatives})
+	}
+
+	if len(clauses) == 0 {
+		return bson.M{}
+	}
+
+	return bson.M{"$and": clauses}
+}
+
+// visible restricts the clause to the engineers whose field the viewer may see.
+func (m *Matcher) visible(field string, clause bson.M) bson.M {
+	allowed := EngineerFieldPolicy.AllowsFilter(field, m.viewer)
+	if len(allowed) == 0 {
+		return clause
+	}
+	return bson.M{"$and": bson.A{allowed, clause}}
+}
+
+// PrescoreExpression is an aggregation expression approximating the score from the factors cheap
+// enough to compute in the database, used to pick which candidates are scored when more than
+// MaxMatchCandidates pass the must-haves. Only fields shown to everyone are used, as the
+// expression can't apply engineers' visibility settings.
+func (m *Matcher) PrescoreExpression() bson.M {
+	terms := bson.A{}
+
+	if len(m.spec.RoleTypes) > 0 && EngineerFieldPolicy.IsPublic("RoleType") {
+		terms = append(terms, intersectsExpression("$role_type", m.spec.RoleTypes, m.weights[MatchFactorRoleType]))
+	}
+
+	if len(m.spec.RoleLevels) > 0 && EngineerFieldPolicy.IsPublic("RoleLevel") {
+		terms = append(terms, intersectsExpression("$role_level", m.spec.RoleLevels, m.weights[MatchFactorRoleLevel]))
+	}
+
+	if EngineerFieldPolicy.IsPublic("SearchStatus") {
+		weight := m.weights[MatchFactorSearchStatus]
+		terms = append(terms, bson.M{"$switch": bson.M{
+			"branches": bson.A{
+				bson.M{"case": bson.M{"$eq": bson.A{"$search_status", SearchStatusActivelyLooking}}, "then": weight},
+				bson.M{"case": bson.M{"$eq": bson.A{"$search_status", SearchStatusOpen}}, "then": 0.6 * weight},
+			},
+			"default": 0,
+		}})
+	}
+
+	return bson.M{"$add": append(terms, 0)}
+}
+
+func intersectsExpression(field string, wanted []string, weight float64) bson.M {
+	return bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{bson.M{"$size": bson.M{"$setIntersection": bson.A{bson.M{"$ifNull": bson.A{field, bson.A{}}}, wanted}}}, 0}},
+		weight,
+		0,
+	}}
+}
+
+// Rank scores the engineers and returns the best ones first, at most limit of them.
+func (m *Matcher) Rank(engineers []*Engineer) []*ScoredEngineer {
+	scored := make([]*ScoredEngineer, 0, len(engineers))
+	for _, engineer := range engineers {
+		scored = append(scored, m.Score(engineer))
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+
+	limit := m.spec.Limit
+	if limit == 0 {
+		limit = 20
+	}
+	if len(scored) > limit {
+		scored = scored[:limit]
+	}
+
+	return scored
+}
+
+// Score is the weighted average of the factors the spec asks about, from 0 to 100. A factor
+// resting on fields the engineer doesn't share with the viewer is neutral: it is listed with no
+// weight, so it neither raises nor lowers the score.
+func (m *Matcher) Score(e *Engineer) *ScoredEngineer {
+	factors := []MatchFactor{}
+	add := func(name string, scorer func(e *Engineer) (float64, string, bool)) {
+		score, explanation, shared := scorer(e)
+		if !shared {
+			factors = append(factors, MatchFactor{Name: name, Explanation: "not shared with you"})
+			return
+		}
+		factors = append(factors, MatchFactor{Name: name, Weight: m.weights[name], Score: math.Round(score*100) / 100, Explanation: explanation})
+	}
+
+	if len(m.spec.RoleTypes) > 0 {
+		add(MatchFactorRoleType, m.roleTypeScore)
+	}
+	if len(m.spec.RoleLevels) > 0 {
+		add(MatchFactorRoleLevel, m.roleLevelScore)
+	}
+	if len(m.locations) > 0 {
+		add(MatchFactorLocation, m.locationScore)
+	}
+	add(MatchFactorSearchStatus, m.searchStatusScore)
+	if len(m.keywords) > 0 {
+		add(MatchFactorText, m.textScore)
+	}
+
+	total, weights := 0.0, 0.0
+	for _, factor := range factors {
+		total += factor.Weight * factor.Score
+		weights += factor.Weight
+	}
+
+	score := 0.0
+	if weights > 0 {
+		score = math.Round(total/weights*1000) / 10
+	}
+
+	return &ScoredEngineer{Engineer: e, Score: score, Factors: factors}
+}
+
+// shares tells whether the viewer may see the field of the engineer.
+func (m *Matcher) shares(e *Engineer, field string) bool {
+	return EngineerFieldPolicy.Allows(field, e, m.viewer.Audience(e))
+}
+
+func (m *Matcher) roleTypeScore(e *Engineer) (float64, string, bool) {
+	if !m.shares(e, "RoleType") {
+		return 0, "", false
+	}
+
+	matching := intersect(m.spec.RoleTypes, e.RoleType)
+	if len(matching) > 0 {
+		return 1, "open to " + strings.Join(matching, ", "), true
+	}
+	return 0, "not open to " + strings.Join(m.spec.RoleTypes, ", "), true
+}
+
+// roleLevelScore gives half the points to engineers one level away from a requested level.
+func (m *Matcher) roleLevelScore(e *Engineer) (float64, string, bool) {
+	if !m.shares(e, "RoleLevel") {
+		return 0, "", false
+	}
+
+	matching := intersect(m.spec.RoleLevels, e.RoleLevel)
+	if len(matching) > 0 {
+		return 1, "at level " + strings.Join(matching, ", "), true
+	}
+
+	for _, wanted := range m.spec.RoleLevels {
+		for _, level := range e.RoleLevel {
+			distance := roleLevelOrder[wanted] - roleLevelOrder[level]
+			if distance == 1 || distance == -1 {
+				return 0.5, fmt.Sprintf("at level %s, next to %s", level, wanted), true
+			}
+		}
+	}
+
+	return 0, "at level " + strings.Join(e.RoleLevel, ", "), true
+}
+
+// locationScore only compares the location fields the engineer shares. When none of those match
+// and some are concealed, the engineer may still be in a requested location, so the factor is
+// neutral rather than a miss.
+func (m *Matcher) locationScore(e *Engineer) (float64, string, bool) {
+	sharesTimezone := m.shares(e, "Timezone")
+	sharesLocation := m.shares(e, "Location")
+	sharesCountry := m.shares(e, "CountryCode")
+
+	best, explanation := 0.0, "outside the requested locations"
+	consider := func(score float64, text string) {
+		if score > best {
+			best, explanation = score, text
+		}
+	}
+
+	for _, location := range m.locations {
+		if sharesTimezone {
+			for _, prefix := range location.Region {
+				if strings.HasPrefix(e.Timezone, prefix) {
+					consider(1, "in "+location.Name)
+				}
+			}
+		}
+
+		if sharesLocation && location.Point != nil && e.Location != nil {
+			distance := location.Point.DistanceKm(e.Location)
+			if distance <= matchNearbyKm {
+				consider(1, fmt.Sprintf("%.0f km from %s", distance, location.Name))
+			}
+		}
+
+		if sharesCountry && location.CountryCode != "" && location.CountryCode == e.CountryCode {
+			if location.Point == nil {
+				consider(1, "in "+location.Name)
+			} else {
+				consider(0.6, "in the same country as "+location.Name)
+			}
+		}
+	}
+
+	if best == 0 && !(sharesTimezone && sharesLocation && sharesCountry) {
+		return 0, "", false
+	}
+
+	return best, explanation, true
+}
+
+func (m *Matcher) searchStatusScore(e *Engineer) (float64, string, bool) {
+	if !m.shares(e, "SearchStatus") {
+		return 0, "", false
+	}
+
+	switch e.SearchStatus {
+	case SearchStatusActivelyLooking:
+		return 1, "actively looking", true
+	case SearchStatusOpen:
+		return 0.6, "open to offers", true
+	default:
+		return 0, "not looking", true
+	}
+}
+
+// textScore searches the keywords in the tagline and bio, skipping whichever is concealed. As
+// for locations, finding none of them is only a miss when both are shared.
+func (m *Matcher) textScore(e *Engineer) (float64, string, bool) {
+	texts := []string{}
+	if m.shares(e, "Tagline") {
+		texts = append(texts, e.Tagline)
+	}
+	if m.shares(e, "Bio") {
+		texts = append(texts, e.Bio)
+	}
+
+	text := strings.ToLower(strings.Join(texts, " "))
+
+	matched := []string{}
+	for _, keyword := range m.keywords {
+		if strings.Contains(text, keyword) {
+			matched = append(matched, keyword)
+		}
+	}
+
+	if len(matched) == 0 {
+		if len(texts) < 2 {
+			return 0, "", false
+		}
+		return 0, "mentions none of the keywords", true
+	}
+
+	return float64(len(matched)) / float64(len(m.keywords)), "mentions " + strings.Join(matched, ", "), true
+}
+
+func intersect(wanted, values []string) []string {
+	matching := []string{}
+	for _, w := range wanted {
+		for _, value := range values {
+			if value == w {
+				matching = append(matching, w)
+				break
+			}
+		}
+	}
+	return matching
+}
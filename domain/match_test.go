@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMatcherScoresSharedFieldsOnly(t *testing.T) {
+	member := &Viewer{UserID: uuid.New(), IsMember: true}
+	berlin := NewGeoPoint(52.52, 13.405)
+
+	tests := []struct {
+		name            string
+		viewer          *Viewer
+		engineer        *Engineer
+		wantWeight      float64
+		wantScore       float64
+		wantExplanation string
+	}{
+		{
+			"shared location",
+			member,
+			&Engineer{ID: uuid.New(), Location: berlin, CountryCode: "DE", Timezone: "Europe/Berlin"},
+			20, 1, "0 km from Berlin, Germany",
+		},
+		{
+			"concealed location matching nothing else",
+			member,
+			&Engineer{ID: uuid.New(), Location: berlin, FieldVisibility: map[string]string{"Location": VisibilityContacts}},
+			0, 0, "not shared with you",
+		},
+		{
+			"concealed location still matching the country",
+			member,
+			&Engineer{ID: uuid.New(), Location: berlin, CountryCode: "DE", FieldVisibility: map[string]string{"Location": VisibilityContacts}},
+			20, 0.6, "in the same country as Berlin, Germany",
+		},
+		{
+			"contact sees the location",
+			&Viewer{UserID: member.UserID, IsMember: true, Contacts: map[uuid.UUID]bool{uuid.Nil: true}},
+			&Engineer{Location: berlin, FieldVisibility: map[string]string{"Location": VisibilityContacts}},
+			20, 1, "0 km from Berlin, Germany",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := NewMatcher(context.Background(), &MatchSpec{Locations: []string{"Berlin, Germany"}}, tt.viewer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var location *MatchFactor
+			factors := matcher.Score(tt.engineer).Factors
+			for i := range factors {
+				if factors[i].Name == MatchFactorLocation {
+					location = &factors[i]
+				}
+			}
+
+			if location == nil {
+				t.Fatal("Score() has no location factor")
+			}
+			if location.Weight != tt.wantWeight || location.Score != tt.wantScore || location.Explanation != tt.wantExplanation {
+				t.Errorf("Score() location = %+v, want weight %v, score %v, %q", *location, tt.wantWeight, tt.wantScore, tt.wantExplanation)
+			}
+		})
+	}
+}
+
+func TestFieldPolicyAllowsFilter(t *testing.T) {
+	ownerID := uuid.New()
+	contactID := uuid.New()
+
+	engineer := func(id uuid.UUID, settings bson.M) bson.M {
+		doc := bson.M{"_id": id, "user_id": ownerID}
+		if settings != nil {
+			doc["field_visibility"] = settings
+		}
+		return doc
+	}
+
+	tests := []struct {
+		name     string
+		viewer   *Viewer
+		engineer bson.M
+		want     bool
+	}{
+		{"member sees default location", &Viewer{UserID: uuid.New(), IsMember: true}, engineer(uuid.New(), nil), true},
+		{"anonymous doesn't see default location", &Viewer{}, engineer(uuid.New(), nil), false},
+		{"anonymous sees public location", &Viewer{}, engineer(uuid.New(), bson.M{"Location": VisibilityPublic}), true},
+		{"member doesn't see contacts location", &Viewer{UserID: uuid.New(), IsMember: true}, engineer(uuid.New(), bson.M{"Location": VisibilityContacts}), false},
+		{"contact sees contacts location", &Viewer{UserID: uuid.New(), IsMember: true, Contacts: map[uuid.UUID]bool{contactID: true}}, engineer(contactID, bson.M{"Location": VisibilityContacts}), true},
+		{"owner sees own location", &Viewer{UserID: ownerID}, engineer(uuid.New(), bson.M{"Location": VisibilityOwner}), true},
+		{"admin sees owner location", &Viewer{UserID: uuid.New(), IsAdmin: true}, engineer(uuid.New(), bson.M{"Location": VisibilityOwner}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := EngineerFieldPolicy.AllowsFilter("Location", tt.viewer)
+			if got := matchesFilter(tt.engineer, filter); got != tt.want {
+				t.Errorf("filter %v matched = %v, want %v", filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldPolicyAllowsByDefault(t *testing.T) {
+	tests := []struct {
+		name   string
+		field  string
+		viewer *Viewer
+		want   bool
+	}{
+		{"anonymous doesn't see locations", "Location", &Viewer{}, false},
+		{"authenticated doesn't see locations", "Location", &Viewer{UserID: uuid.New()}, false},
+		{"member sees locations", "Location", &Viewer{UserID: uuid.New(), IsMember: true}, true},
+		{"admin sees locations", "Location", &Viewer{UserID: uuid.New(), IsAdmin: true}, true},
+		{"member doesn't see linkedin", "LinkedIn", &Viewer{UserID: uuid.New(), IsMember: true}, false},
+		{"member sees stats following github", "GithubStats", &Viewer{UserID: uuid.New(), IsMember: true}, true},
+		{"member doesn't see undeclared fields", "Password", &Viewer{UserID: uuid.New(), IsMember: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EngineerFieldPolicy.AllowsByDefault(tt.field, tt.viewer); got != tt.want {
+				t.Errorf("AllowsByDefault(%q) = %v, want %v", tt.field, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"crypto/md5"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Conversation is the single thread between a recruiter and an engineer. Unread counts are
+// keyed by the participant's user id.
+type Conversation struct {
+	ID uuid.UUID					`bson:"_id,required"`
+	RecruiterID uuid.UUID			`bson:"recruiter_id,required"`
+	RecruiterUserID uuid.UUID		`bson:"recruiter_user_id,required"`
+	EngineerID uuid.UUID			`bson:"engineer_id,required"`
+	EngineerUserID uuid.UUID		`bson:"engineer_user_id,required"`
+	ParticipantIDs []uuid.UUID		`bson:"participant_ids,required"`
+	UnreadCounts map[string]int		`bson:"unread_counts"`
+	LastMessagePreview string		`bson:"last_message_preview,omitempty"`
+	LastMessageAt time.Time			`bson:"last_message_at,required"`
+	CreatedAt time.Time				`bson:"created_at,required"`
+}
+
+type Message struct {
+	ID uuid.UUID				`bson:"_id,required"`
+	ConversationID uuid.UUID	`bson:"conversation_id,required"`
+	SenderID uuid.UUID			`bson:"sender_id,required"`
+	RecipientID uuid.UUID		`bson:"recipient_id,required"`
+	Body string					`bson:"body,required"`
+	CreatedAt time.Time			`bson:"created_at,required"`
+	ReadAt *time.Time			`bson:"read_at,omitempty"`
+	NotifiedAt *time.Time		`bson:"notified_at,omitempty" json:"-"`
+}
+
+// Block prevents the blocked user from exchanging messages with the blocker.
+type Block struct {
+	ID uuid.UUID			`bson:"_id,required"`
+	BlockerID uuid.UUID		`bson:"blocker_id,required"`
+	BlockedID uuid.UUID		`bson:"blocked_id,required"`
+	CreatedAt time.Time		`bson:"created_at,required"`
+}
+
+type StartConversationPayload struct {
+	EngineerID string	`json:"engineerId" validate:"required,uuid"`
+	Body string			`json:"body" validate:"required,max=5000"`
+}
+
+type SendMessagePayload struct {
+	Body string			`json:"body" validate:"required,max=5000"`
+}
+
+const messagePreviewLength = 140
+
+// NewConversation derives the id from both participants so that there is one thread per pair.
+func NewConversation(recruiter *Recruiter, engineer *Engineer) (*Conversation, error) {
+	pairHash := md5.Sum([]byte(recruiter.UserID.String() + engineer.UserID.String()))
+	conversationID, err := uuid.FromBytes(pairHash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Conversation{
+		ID: conversationID,
+		RecruiterID: recruiter.ID,
+		RecruiterUserID: recruiter.UserID,
+		EngineerID: engineer.ID,
+		EngineerUserID: engineer.UserID,
+		ParticipantIDs: []uuid.UUID{recruiter.UserID, engineer.UserID},
+		UnreadCounts: map[string]int{},
+		LastMessageAt: now,
+		CreatedAt: now,
+	}, nil
+}
+
+func NewBlock(blockerID, blockedID uuid.UUID) (*Block, error) {
+	pairHash := md5.Sum([]byte(blockerID.String() + blockedID.String()))
+	blockID, err := uuid.FromBytes(pairHash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Block{
+		ID: blockID,
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (c *Conversation) HasParticipant(userID uuid.UUID) bool {
+	for _, participantID := range c.ParticipantIDs {
+		if participantID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// OtherParticipant returns the user on the other side of the thread.
+func (c *Conversation) OtherParticipant(userID uuid.UUID) uuid.UUID {
+	if userID == c.RecruiterUserID {
+		return c.EngineerUserID
+	}
+	return c.RecruiterUserID
+}
+
+func (c *Conversation) NewMessage(senderID uuid.UUID, body string) *Message {
+	return &Message{
+		ID: uuid.New(),
+		ConversationID: c.ID,
+		SenderID: senderID,
+		RecipientID: c.OtherParticipant(senderID),
+		Body: body,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Preview is the start of the message shown in conversation lists.
+func (m *Message) Preview() string {
+	runes := []rune(m.Body)
+	if len(runes) <= messagePreviewLength {
+		return m.Body
+	}
+	return string(runes[:messagePreviewLength]) + "…"
+}
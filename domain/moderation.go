@@ -0,0 +1,499 @@
+package domain
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ModerationFlagOpen      = "open"
+	ModerationFlagDismissed = "dismissed"
+	ModerationFlagActioned  = "actioned"
+
+	ModerationSourceAutomatic = "automatic"
+	ModerationSourceReport    = "report"
+	ModerationSourceDuplicate = "duplicate"
+)
+
+// Reasons a field is rejected or flagged for.
+const (
+	ModerationReasonBlockedWord    = "blocked_word"
+	ModerationReasonLinkDomain     = "link_domain"
+	ModerationReasonWatchedWord    = "watched_word"
+	ModerationReasonTooManyLinks   = "too_many_links"
+	ModerationReasonShortenedLink  = "shortened_link"
+	ModerationReasonContactDetails = "contact_details"
+	ModerationReasonRepetition     = "repetition"
+	ModerationReasonShouting       = "shouting"
+	ModerationReasonReport         = "report"
+	ModerationReasonDuplicate      = "duplicate"
+)
+
+const (
+	// maxLinksInText is how many links a text holds before it reads like an ad rather than a bio.
+	maxLinksInText = 2
+	// maxRepeatedChars is how many times a letter or a "!" or "?" may repeat in a row.
+	maxRepeatedChars = 5
+	// minShoutingLetters and shoutingRatio flag texts of some length written mostly in capitals.
+	minShoutingLetters = 20
+	shoutingRatio      = 0.7
+	// maxFlagDetails bounds the text kept on a flag for the admin reviewing it.
+	maxFlagDetails = 500
+)
+
+//go:embed data/watchlist.txt
+var watchList string
+
+var (
+	linkPattern  = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"']+`)
+	emailPattern = regexp.MustCompile(`(?i)\b[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}\b`)
+	phonePattern = regexp.MustCompile(`\+?\d[\d .()-]{7,}\d`)
+)
+
+// linkDomains are the sites profile links must point at, by field. Subdomains, like
+// "de.linkedin.com", are accepted too.
+var linkDomains = map[string]string{
+	"Github":        "github.com",
+	"LinkedIn":      "linkedin.com",
+	"StackOverflow": "stackoverflow.com",
+}
+
+// shortenerDomains hide where a link leads, which is how spam usually gets past a glance.
+var shortenerDomains = map[string]bool{
+	"bit.ly": true, "buff.ly": true, "cutt.ly": true, "goo.gl": true, "is.gd": true, "ow.ly": true,
+	"rebrand.ly": true, "shorturl.at": true, "t.co": true, "t.ly": true, "tiny.cc": true,
+	"tinyurl.com": true,
+}
+
+// WordList holds single words, matched against whole words of a text so that "scunthorpe" is
+// fine, and phrases, matched against runs of whole words.
+type WordList struct {
+	words   map[string]bool
+	phrases []string
+}
+
+// parseWordList reads one lowercase word or phrase per line, ignoring lines starting with #.
+func parseWordList(list string) *WordList {
+	wordList := &WordList{words: map[string]bool{}}
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if words := strings.Fields(line); len(words) > 1 {
+			wordList.phrases = append(wordList.phrases, strings.Join(words, " "))
+		} else {
+			wordList.words[line] = true
+		}
+	}
+	return wordList
+}
+
+// Match returns the first listed word or phrase found in the text, or "" when there is none.
+func (l *WordList) Match(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, word := range words {
+		if l.words[word] {
+			return word
+		}
+	}
+
+	if len(l.phrases) > 0 {
+		joined := " " + strings.Join(words, " ") + " "
+		for _, phrase := range l.phrases {
+			if strings.Contains(joined, " "+phrase+" ") {
+				return phrase
+			}
+		}
+	}
+
+	return ""
+}
+
+// Moderator checks the text and links users put on their profile. Words of the Blocklist get
+// the change rejected, while those of the Watchlist, like spam heuristics, only flag the profile
+// for an admin to review.
+type Moderator struct {
+	Blocklist *WordList
+	Watchlist *WordList
+}
+
+var moderator *Moderator
+
+func SetModerator(m *Moderator) {
+	moderator = m
+}
+
+func CurrentModerator() *Moderator {
+	if moderator == nil {
+		moderator = &Moderator{Blocklist: parseWordList(profanityList), Watchlist: parseWordList(watchList)}
+	}
+	return moderator
+}
+
+// NewModeratorFromEnv uses the lists bundled with the binary, unless MODERATION_BLOCKLIST_FILE or
+// MODERATION_WATCHLIST_FILE point at files, in the same format, replacing them.
+func NewModeratorFromEnv() (*Moderator, error) {
+	blocklist, err := wordListFromFile(os.Getenv("MODERATION_BLOCKLIST_FILE"), profanityList)
+	if err != nil {
+		return nil, err
+	}
+
+	watchlist, err := wordListFromFile(os.Getenv("MODERATION_WATCHLIST_FILE"), watchList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Moderator{Blocklist: blocklist, Watchlist: watchlist}, nil
+}
+
+func wordListFromFile(path, bundled string) (*WordList, error) {
+	if path == "" {
+		return parseWordList(bundled), nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read word list: %w", err)
+	}
+
+	return parseWordList(string(content)), nil
+}
+
+// ProfileContent is what gets moderated of a profile change: free text and links, keyed by
+// field name. Fields left empty by the change are skipped.
+type ProfileContent struct {
+	Texts map[string]string
+	Links map[string]string
+}
+
+// ModerationIssue is a field rejected or flagged, and why. Details is the offending text, or for
+// reports, what the reporter wrote.
+type ModerationIssue struct {
+	Field string
+	Reason string
+	Details string
+}
+
+type ModerationResult struct {
+	Rejected []ModerationIssue
+	Flagged []ModerationIssue
+}
+
+// Err describes the rejected fields to the user, without repeating the words that got them
+// rejected. It is nil when nothing was rejected.
+func (r *ModerationResult) Err() error {
+	if len(r.Rejected) == 0 {
+		return nil
+	}
+
+	messages := []string{}
+	for _, issue := range r.Rejected {
+		switch issue.Reason {
+		case ModerationReasonLinkDomain:
+			messages = append(messages, fmt.Sprintf("%s must be a link to %s", issue.Field, linkDomains[issue.Field]))
+		default:
+			messages = append(messages, fmt.Sprintf("%s contains inappropriate language", issue.Field))
+		}
+	}
+
+	return errors.New(strings.Join(messages, "; "))
+}
+
+// Moderate checks every field of the content, in a stable order so that results can be compared.
+func (m *Moderator) Moderate(content ProfileContent) *ModerationResult {
+	result := &ModerationResult{}
+
+	for _, field := range sortedKeys(content.Links) {
+		m.moderateLink(result, field, content.Links[field])
+	}
+
+	for _, field := range sortedKeys(content.Texts) {
+		m.moderateText(result, field, content.Texts[field])
+	}
+
+	return result
+}
+
+func (m *Moderator) moderateLink(result *ModerationResult, field, link string) {
+	if link == "" {
+		return
+	}
+
+	host := linkHost(link)
+	if domain, ok := linkDomains[field]; ok {
+		if host != domain && !strings.HasSuffix(host, "."+domain) {
+			result.Rejected = append(result.Rejected, ModerationIssue{Field: field, Reason: ModerationReasonLinkDomain, Details: link})
+		}
+		return
+	}
+
+	if shortenerDomains[host] {
+		result.Flagged = append(result.Flagged, ModerationIssue{Field: field, Reason: ModerationReasonShortenedLink, Details: link})
+	}
+}
+
+func (m *Moderator) moderateText(result *ModerationResult, field, text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+
+	details := truncateRunes(text, maxFlagDetails)
+	if m.Blocklist.Match(text) != "" {
+		result.Rejected = append(result.Rejected, ModerationIssue{Field: field, Reason: ModerationReasonBlockedWord, Details: details})
+		return
+	}
+
+	flag := func(reason string) {
+		result.Flagged = append(result.Flagged, ModerationIssue{Field: field, Reason: reason, Details: details})
+	}
+
+	if m.Watchlist.Match(text) != "" {
+		flag(ModerationReasonWatchedWord)
+	}
+
+	links := linkPattern.FindAllString(text, -1)
+	if len(links) > maxLinksInText {
+		flag(ModerationReasonTooManyLinks)
+	}
+
+	for _, link := range links {
+		if shortenerDomains[linkHost(link)] {
+			flag(ModerationReasonShortenedLink)
+			break
+		}
+	}
+
+	if emailPattern.MatchString(text) || hasPhoneNumber(text) {
+		flag(ModerationReasonContactDetails)
+	}
+
+	if hasRepeatedChars(text) {
+		flag(ModerationReasonRepetition)
+	}
+
+	if isShouting(text) {
+		flag(ModerationReasonShouting)
+	}
+}
+
+// linkHost returns the lowercase host of the link, without "www.", accepting links written
+// without a scheme.
+func linkHost(link string) string {
+	link = strings.TrimSpace(link)
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// hasPhoneNumber looks for runs of at least 9 digits, allowing the usual separators, so that
+// years and date ranges don't count.
+func hasPhoneNumber(text string) bool {
+	for _, match := range phonePattern.FindAllString(text, -1) {
+		digits := 0
+		for _, r := range match {
+			if unicode.IsDigit(r) {
+				digits++
+			}
+		}
+		if digits >= 9 {
+			return true
+		}
+	}
+	return false
+}
+
+func hasRepeatedChars(text string) bool {
+	var previous rune
+	count := 0
+	for _, r := range strings.ToLower(text) {
+		if r == previous && (unicode.IsLetter(r) || r == '!' || r == '?') {
+			count++
+			if count > maxRepeatedChars {
+				return true
+			}
+			continue
+		}
+		previous = r
+		count = 1
+	}
+	return false
+}
+
+func isShouting(text string) bool {
+	letters, upper := 0, 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= minShoutingLetters && float64(upper) >= shoutingRatio*float64(letters)
+}
+
+func truncateRunes(text string, max int) string {
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	return string([]rune(text)[:max]) + "…"
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (p *CreateEngineerPayload) ModerationContent() ProfileContent {
+	return ProfileContent{
+		Texts: map[string]string{"Tagline": p.Tagline, "Bio": p.Bio},
+		Links: map[string]string{"Github": p.Github, "LinkedIn": p.LinkedIn, "StackOverflow": p.StackOverflow, "Website": p.Website, "Twitter": p.Twitter},
+	}
+}
+
+// ModerationContent includes the section entries set by the update, which only imports do.
+func (p *UpdateEngineerPayload) ModerationContent() ProfileContent {
+	content := ProfileContent{
+		Texts: map[string]string{"Tagline": p.Tagline, "Bio": p.Bio},
+		Links: map[string]string{"StackOverflow": p.StackOverflow, "Website": p.Website, "Twitter": p.Twitter},
+	}
+
+	for i, entry := range p.Experience {
+		content.Texts[fmt.Sprintf("Experience.%d.Description", i)] = entry.Description
+	}
+	for i, entry := range p.Education {
+		content.Texts[fmt.Sprintf("Education.%d.Description", i)] = entry.Description
+	}
+	for i, project := range p.Projects {
+		content.Texts[fmt.Sprintf("Projects.%d.Description", i)] = project.Description
+		content.Links[fmt.Sprintf("Projects.%d.URL", i)] = project.URL
+	}
+
+	return content
+}
+
+func (p *CreateRecruiterPayload) ModerationContent() ProfileContent {
+	return ProfileContent{
+		Texts: map[string]string{"Bio": p.Bio},
+		Links: map[string]string{"LinkedIn": p.LinkedIn, "Website": p.Website},
+	}
+}
+
+func (p *UpdateRecruiterPayload) ModerationContent() ProfileContent {
+	return ProfileContent{
+		Texts: map[string]string{"Bio": p.Bio},
+		Links: map[string]string{"Website": p.Website},
+	}
+}
+
+func (p *ExperiencePayload) ModerationContent() ProfileContent {
+	return ProfileContent{Texts: map[string]string{"Experience.Description": p.Description}}
+}
+
+func (p *EducationPayload) ModerationContent() ProfileContent {
+	return ProfileContent{Texts: map[string]string{"Education.Description": p.Description}}
+}
+
+func (p *PortfolioProjectPayload) ModerationContent() ProfileContent {
+	return ProfileContent{
+		Texts: map[string]string{"Projects.Description": p.Description},
+		Links: map[string]string{"Projects.URL": p.URL},
+	}
+}
+
+// ModerationFlag is a profile waiting for an admin to look at it, flagged by the moderation
+// pipeline or reported by a user.
+type ModerationFlag struct {
+	ID uuid.UUID				`bson:"_id"`
+	ProfileType string			`bson:"profile_type"`
+	ProfileID uuid.UUID			`bson:"profile_id"`
+	UserID uuid.UUID			`bson:"user_id"`
+	Source string				`bson:"source"`
+	ReporterID *uuid.UUID		`bson:"reporter_id,omitempty"`
+	Field string				`bson:"field,omitempty"`
+	Reason string				`bson:"reason"`
+	Details string				`bson:"details,omitempty"`
+	Status string				`bson:"status"`
+	Note string					`bson:"note,omitempty"`
+	CreatedAt time.Time			`bson:"created_at"`
+	ReviewedAt time.Time		`bson:"reviewed_at,omitempty"`
+	ReviewedBy *uuid.UUID		`bson:"reviewed_by,omitempty"`
+}
+
+type ReportProfilePayload struct {
+	ProfileType string		`json:"profileType" validate:"required,oneof=engineer recruiter"`
+	ProfileID string		`json:"profileId" validate:"required,uuid"`
+	Reason string			`json:"reason" validate:"required,max=1000"`
+}
+
+// ReviewModerationFlagPayload closes a flag. Reviewing a report closes every open report on the
+// profile the same way, and HideForDays hides the profile when acting on it.
+type ReviewModerationFlagPayload struct {
+	Status string			`json:"status" validate:"required,oneof=dismissed actioned"`
+	Note string				`json:"note,omitempty" validate:"omitempty,max=1000"`
+	HideForDays int			`json:"hideForDays,omitempty" validate:"omitempty,min=1,max=365"`
+}
+
+// NewModerationFlags turns what the moderator flagged in a profile change into flags to review.
+func NewModerationFlags(result *ModerationResult, profileType string, profileID, userID uuid.UUID, now time.Time) []*ModerationFlag {
+	flags := []*ModerationFlag{}
+	for _, issue := range result.Flagged {
+		flags = append(flags, &ModerationFlag{
+			ID: uuid.New(),
+			ProfileType: profileType,
+			ProfileID: profileID,
+			UserID: userID,
+			Source: ModerationSourceAutomatic,
+			Field: issue.Field,
+			Reason: issue.Reason,
+			Details: issue.Details,
+			Status: ModerationFlagOpen,
+			CreatedAt: now,
+		})
+	}
+	return flags
+}
+
+// NewReportFlag records a user reporting a profile as abusive. A user has at most one open
+// report per profile, so that the reports counted towards hiding it are independent.
+func NewReportFlag(profileType string, profileID, userID, reporterID uuid.UUID, reason string, now time.Time) *ModerationFlag {
+	return &ModerationFlag{
+		ID: uuid.New(),
+		ProfileType: profileType,
+		ProfileID: profileID,
+		UserID: userID,
+		Source: ModerationSourceReport,
+		ReporterID: &reporterID,
+		Reason: ModerationReasonReport,
+		Details: truncateRunes(strings.TrimSpace(reason), maxFlagDetails),
+		Status: ModerationFlagOpen,
+		CreatedAt: now,
+	}
+}
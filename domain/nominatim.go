@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultNominatimURL = "https://nominatim.openstreetmap.org"
+
+// NominatimGeocoder queries an OpenStreetMap Nominatim instance. Nominatim does not report
+// timezones, so those are taken from the nearest city of the bundled dataset.
+type NominatimGeocoder struct {
+	BaseURL string
+	Client  *http.Client
+	Dataset *CityDatasetGeocoder
+}
+
+type nominatimPlace struct {
+	Lat     string `json:"lat"`
+	Lon     string `json:"lon"`
+	Address struct {
+		CountryCode string `json:"country_code"`
+	} `json:"address"`
+}
+
+func NewNominatimGeocoder(baseURL string, dataset *CityDatasetGeocoder) *NominatimGeocoder {
+	if baseURL == "" {
+		baseURL = defaultNominatimURL
+	}
+
+	return &NominatimGeocoder{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Client:  &http.Client{Timeout: 5 * time.Second},
+		Dataset: dataset,
+	}
+}
+
+func (g *NominatimGeocoder) Geocode(ctx context.Context, query GeocodeQuery) (*GeocodeResult, error) {
+	params := url.Values{}
+	params.Set("format", "jsonv2")
+	params.Set("addressdetails", "1")
+	params.Set("limit", "1")
+	if query.City != "" {
+		params.Set("city", query.City)
+	}
+	if query.State != "" {
+		params.Set("state", query.State)
+	}
+	if query.Country != "" {
+		params.Set("country", query.Country)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", g.BaseURL+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "angular-talents-backend")
+
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("nominatim returned status %d", resp.StatusCode)
+	}
+
+	var places []nominatimPlace
+	err = json.NewDecoder(resp.Body).Decode(&places)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(places) == 0 {
+		return g.Dataset.Geocode(ctx, query)
+	}
+
+	lat, err := strconv.ParseFloat(places[0].Lat, 64)
+	if err != nil {
+		return nil, err
+	}
+	lng, err := strconv.ParseFloat(places[0].Lon, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	location := NewGeoPoint(lat, lng)
+	countryCode := strings.ToUpper(places[0].Address.CountryCode)
+
+	return &GeocodeResult{
+		Location:    location,
+		CountryCode: countryCode,
+		Timezone:    g.Dataset.NearestTimezone(location, countryCode),
+	}, nil
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationContactRequest   = "contact_request"
+	NotificationMessage          = "message"
+	NotificationProfileView      = "profile_view"
+	NotificationMembershipChange = "membership_change"
+	NotificationReportOutcome    = "report_outcome"
+)
+
+// notificationBufferSize is how many notifications a slow stream may fall behind before
+// further ones are dropped; they remain available through GET /notifications.
+const notificationBufferSize = 16
+
+type Notification struct {
+	ID uuid.UUID					`bson:"_id,required"`
+	UserID uuid.UUID				`bson:"user_id,required"`
+	Type string						`bson:"type,required"`
+	Data map[string]interface{}		`bson:"data,omitempty"`
+	CreatedAt time.Time				`bson:"created_at,required"`
+	ReadAt *time.Time				`bson:"read_at,omitempty"`
+}
+
+type MarkNotificationsReadPayload struct {
+	IDs []string	`json:"ids" validate:"omitempty,dive,uuid"`
+}
+
+func NewNotification(userID uuid.UUID, notificationType string, data map[string]interface{}) *Notification {
+	return &Notification{
+		ID: uuid.New(),
+		UserID: userID,
+		Type: notificationType,
+		Data: data,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NotificationBroker fans stored notifications out to the streams their user has open.
+type NotificationBroker interface {
+	Publish(notification *Notification)
+	Subscribe(userID uuid.UUID) (<-chan *Notification, func())
+}
+
+var notificationBroker NotificationBroker
+
+func SetNotificationBroker(b NotificationBroker) {
+	notificationBroker = b
+}
+
+func CurrentNotificationBroker() NotificationBroker {
+	if notificationBroker == nil {
+		notificationBroker = NewInProcessBroker()
+	}
+	return notificationBroker
+}
+
+// InProcessBroker only reaches streams served by this instance, which is enough as long as
+// the API runs as a single process.
+type InProcessBroker struct {
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan *Notification]bool
+}
+
+func NewInProcessBroker() *InProcessBroker {
+	return &InProcessBroker{subscribers: map[uuid.UUID]map[chan *Notification]bool{}}
+}
+
+func (b *InProcessBroker) Publish(notification *Notification) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[notification.UserID] {
+		select {
+		case ch <- notification:
+		default:
+		}
+	}
+}
+
+// Subscribe returns the channel of the user's notifications and the function that closes it.
+func (b *InProcessBroker) Subscribe(userID uuid.UUID) (<-chan *Notification, func()) {
+	ch := make(chan *Notification, notificationBufferSize)
+
+	b.mu.Lock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = map[chan *Notification]bool{}
+	}
+	b.subscribers[userID][ch] = true
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subscribers[userID], ch)
+			if len(b.subscribers[userID]) == 0 {
+				delete(b.subscribers, userID)
+			}
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+type Pagination struct {
+	Page int64		`json:"page" bson:"page"`
+	Limit int64		`json:"limit" bson:"limit"`
+}
+
+// NewPagination reads the page and limit query parameters, capping the limit.
+func NewPagination(q url.Values, defaultLimit, maxLimit int64) (*Pagination, error) {
+	pagination := &Pagination{Page: 1, Limit: defaultLimit}
+
+	if q.Get("page") != "" {
+		page, err := strconv.ParseInt(q.Get("page"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if page < 1 {
+			return nil, fmt.Errorf("page must be at least 1")
+		}
+		pagination.Page = page
+	}
+
+	if q.Get("limit") != "" {
+		limit, err := strconv.ParseInt(q.Get("limit"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if limit < 1 || limit > maxLimit {
+			return nil, fmt.Errorf("limit must be between 1 and %d", maxLimit)
+		}
+		pagination.Limit = limit
+	}
+
+	return pagination, nil
+}
+
+func (p *Pagination) Skip() int64 {
+	return (p.Page - 1) * p.Limit
+}
@@ -0,0 +1,291 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// A4 in PDF points.
+const (
+	PDFPageWidth  = 595.28
+	PDFPageHeight = 841.89
+)
+
+type PDFFont int
+
+// Only the standard Helvetica faces are used: every PDF reader ships them, so nothing has to be
+// embedded and the files stay small.
+const (
+	PDFRegular PDFFont = iota
+	PDFBold
+)
+
+var pdfFontNames = map[PDFFont]string{
+	PDFRegular: "Helvetica",
+	PDFBold:    "Helvetica-Bold",
+}
+
+// Advance widths of the printable ASCII characters, from the Adobe font metrics, in thousandths
+// of the font size. Other characters are measured with pdfDefaultWidth.
+var pdfFontWidths = map[PDFFont][95]int{
+	PDFRegular: {
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	PDFBold: {
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+}
+
+const pdfDefaultWidth = 556
+
+// PDFColor is an RGB color with components between 0 and 1.
+type PDFColor struct {
+	R, G, B float64
+}
+
+func PDFHexColor(hex uint32) PDFColor {
+	return PDFColor{
+		R: float64(hex>>16&0xFF) / 255,
+		G: float64(hex>>8&0xFF) / 255,
+		B: float64(hex&0xFF) / 255,
+	}
+}
+
+type pdfLink struct {
+	x, y, width, height float64
+	uri                 string
+}
+
+type pdfPage struct {
+	content bytes.Buffer
+	links   []pdfLink
+}
+
+// PDFDocument is a minimal PDF 1.4 writer covering what generated documents need: text in the
+// standard fonts, filled rectangles, lines and links. Coordinates are in points from the
+// bottom-left corner of the page, as in PDF itself.
+type PDFDocument struct {
+	Title     string
+	CreatedAt time.Time
+	pages     []*pdfPage
+	current   *pdfPage
+}
+
+func NewPDFDocument(title string, createdAt time.Time) *PDFDocument {
+	return &PDFDocument{Title: title, CreatedAt: createdAt}
+}
+
+func (d *PDFDocument) AddPage() {
+	d.current = &pdfPage{}
+	d.pages = append(d.pages, d.current)
+}
+
+func (d *PDFDocument) PageCount() int {
+	return len(d.pages)
+}
+
+// SetPage moves drawing back to an earlier page, numbered from 1, e.g. to add footers once the
+// number of pages is known.
+func (d *PDFDocument) SetPage(number int) {
+	d.current = d.pages[number-1]
+}
+
+func (d *PDFDocument) Text(x, y float64, font PDFFont, size float64, color PDFColor, text string) {
+	fmt.Fprintf(&d.current.content, "BT %s rg /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		pdfColorOperands(color), font+1, pdfNumber(size), pdfNumber(x), pdfNumber(y), pdfEscape(text))
+}
+
+func (d *PDFDocument) Rect(x, y, width, height float64, color PDFColor) {
+	fmt.Fprintf(&d.current.content, "%s rg %s %s %s %s re f\n",
+		pdfColorOperands(color), pdfNumber(x), pdfNumber(y), pdfNumber(width), pdfNumber(height))
+}
+
+func (d *PDFDocument) Line(x1, y1, x2, y2, width float64, color PDFColor) {
+	fmt.Fprintf(&d.current.content, "%s RG %s w %s %s m %s %s l S\n",
+		pdfColorOperands(color), pdfNumber(width), pdfNumber(x1), pdfNumber(y1), pdfNumber(x2), pdfNumber(y2))
+}
+
+// Link makes the area clickable, opening the URI.
+func (d *PDFDocument) Link(x, y, width, height float64, uri string) {
+	d.current.links = append(d.current.links, pdfLink{x: x, y: y, width: width, height: height, uri: uri})
+}
+
+// Bytes serializes the document: catalog, page tree, the two fonts, then each page with its
+// content stream and link annotations, followed by the cross-reference table.
+func (d *PDFDocument) Bytes() []byte {
+	var out bytes.Buffer
+	offsets := []int{}
+
+	// objects are numbered in the order they are written, starting from 1
+	writeObject := func(body string) int {
+		offsets = append(offsets, out.Len())
+		number := len(offsets)
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", number, body)
+		return number
+	}
+
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	// pages come after the catalog, page tree, fonts and info, each followed by its content
+	// stream and links
+	pageNumbers := []string{}
+	next := 6
+	for _, page := range d.pages {
+		pageNumbers = append(pageNumbers, fmt.Sprintf("%d 0 R", next))
+		next += 2 + len(page.links)
+	}
+
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(pageNumbers, " "), len(d.pages)))
+	writeObject(pdfFontObject(PDFRegular))
+	writeObject(pdfFontObject(PDFBold))
+	info := writeObject(fmt.Sprintf("<< /Title (%s) /Producer (Angular Talents) /CreationDate (D:%s) >>",
+		pdfEscape(d.Title), d.CreatedAt.UTC().Format("20060102150405Z")))
+
+	for _, page := range d.pages {
+		pageNumber := len(offsets) + 1
+		contentNumber := pageNumber + 1
+
+		annotations := []string{}
+		for i := range page.links {
+			annotations = append(annotations, fmt.Sprintf("%d 0 R", contentNumber+1+i))
+		}
+
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R /Annots [%s] >>",
+			pdfNumber(PDFPageWidth), pdfNumber(PDFPageHeight), contentNumber, strings.Join(annotations, " ")))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+
+		for _, link := range page.links {
+			writeObject(fmt.Sprintf("<< /Type /Annot /Subtype /Link /Rect [%s %s %s %s] /Border [0 0 0] /A << /S /URI /URI (%s) >> >>",
+				pdfNumber(link.x), pdfNumber(link.y), pdfNumber(link.x+link.width), pdfNumber(link.y+link.height), pdfEscape(link.uri)))
+		}
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, info, xref)
+
+	return out.Bytes()
+}
+
+func pdfFontObject(font PDFFont) string {
+	return fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", pdfFontNames[font])
+}
+
+// PDFTextWidth measures the text in points.
+func PDFTextWidth(font PDFFont, size float64, text string) float64 {
+	widths := pdfFontWidths[font]
+
+	total := 0
+	for _, r := range text {
+		if r >= 32 && r <= 126 {
+			total += widths[r-32]
+		} else {
+			total += pdfDefaultWidth
+		}
+	}
+
+	return float64(total) * size / 1000
+}
+
+// PDFWrapText breaks the text into lines no wider than maxWidth, keeping the paragraphs of the
+// original text. Words longer than a line are cut.
+func PDFWrapText(font PDFFont, size float64, text string, maxWidth float64) []string {
+	lines := []string{}
+
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+
+			if PDFTextWidth(font, size, candidate) <= maxWidth {
+				line = candidate
+				continue
+			}
+
+			if line != "" {
+				lines = append(lines, line)
+			}
+
+			line = word
+			for PDFTextWidth(font, size, line) > maxWidth {
+				cut := pdfFittingPrefix(font, size, line, maxWidth)
+				lines = append(lines, line[:cut])
+				line = line[cut:]
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func pdfFittingPrefix(font PDFFont, size float64, text string, maxWidth float64) int {
+	cut := 0
+	for i, r := range text {
+		end := i + utf8.RuneLen(r)
+		if cut > 0 && PDFTextWidth(font, size, text[:end]) > maxWidth {
+			break
+		}
+		cut = end
+	}
+	return cut
+}
+
+// pdfEscape encodes the text for a literal string in WinAnsiEncoding, which covers the accented
+// letters of western languages; other characters are replaced with a question mark.
+func pdfEscape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		c, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			c = '?'
+		}
+
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 32 || c > 126:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+func pdfColorOperands(color PDFColor) string {
+	return pdfNumber(color.R) + " " + pdfNumber(color.G) + " " + pdfNumber(color.B)
+}
+
+func pdfNumber(value float64) string {
+	formatted := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.3f", value), "0"), ".")
+	if formatted == "" || formatted == "-0" {
+		return "0"
+	}
+	return formatted
+}
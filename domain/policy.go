@@ -0,0 +1,304 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Audience ranks who is looking at a profile. A field is shown when the viewer's audience is at
+// least the one required by the field's visibility level.
+type Audience int
+
+const (
+	AudienceAnonymous Audience = iota
+	AudienceAuthenticated
+	AudienceMember
+	AudienceContact
+	AudienceOwner
+	AudienceAdmin
+)
+
+const (
+	VisibilityPublic        = "public"
+	VisibilityAuthenticated = "authenticated"
+	VisibilityMembers       = "members"
+	VisibilityContacts      = "contacts"
+	VisibilityOwner         = "owner"
+)
+
+var visibilityAudiences = map[string]Audience{
+	VisibilityPublic:        AudienceAnonymous,
+	VisibilityAuthenticated: AudienceAuthenticated,
+	VisibilityMembers:       AudienceMember,
+	VisibilityContacts:      AudienceContact,
+	VisibilityOwner:         AudienceOwner,
+}
+
+// FieldRule is the default visibility of a field, and whether engineers may override it. A field
+// that Follows another one is shown exactly when that one is.
+type FieldRule struct {
+	Default      string
+	Configurable bool
+	Follows      string
+}
+
+// FieldPolicy maps the JSON name of a field to its rule. Fields missing from the policy are never
+// shown to anyone but owners and admins, so new fields stay private until declared here.
+type FieldPolicy map[string]FieldRule
+
+var EngineerFieldPolicy = FieldPolicy{
+	"ID":              {Default: VisibilityPublic},
+	"UserID":          {Default: VisibilityPublic},
+	"Slug":            {Default: VisibilityPublic},
+	"Tagline":         {Default: VisibilityPublic},
+	"City":            {Default: VisibilityPublic, Configurable: true},
+	"State":           {Default: VisibilityPublic, Configurable: true},
+	"Country":         {Default: VisibilityPublic},
+	"CountryCode":     {Default: VisibilityPublic},
+	"Timezone":        {Default: VisibilityPublic},
+	"Location":        {Default: VisibilityMembers, Configurable: true},
+	"Avatar":          {Default: VisibilityPublic, Configurable: true},
+	"AvatarImage":     {Default: VisibilityPublic, Follows: "Avatar"},
+	"Bio":             {Default: VisibilityPublic},
+	"SearchStatus":    {Default: VisibilityPublic},
+	"RoleType":        {Default: VisibilityPublic},
+	"RoleLevel":       {Default: VisibilityPublic},
+	"Firstname":       {Default: VisibilityMembers, Configurable: true},
+	"Lastname":        {Default: VisibilityMembers, Configurable: true},
+	"Website":         {Default: VisibilityContacts, Configurable: true},
+	"Github":          {Default: VisibilityMembers, Configurable: true},
+	"GithubStats":     {Default: VisibilityMembers, Follows: "Github"},
+	"Twitter":         {Default: VisibilityContacts, Configurable: true},
+	"LinkedIn":        {Default: VisibilityContacts, Configurable: true},
+	"StackOverflow":   {Default: VisibilityContacts, Configurable: true},
+	"Experience":      {Default: VisibilityMembers, Configurable: true},
+	"Education":       {Default: VisibilityMembers, Configurable: true},
+	"Projects":        {Default: VisibilityMembers, Configurable: true},
+	"FieldVisibility": {Default: VisibilityOwner},
+	"CreatedAt":       {Default: VisibilityPublic},
+	"UpdatedAt":       {Default: VisibilityPublic},
+}
+
+// Audience tells how much of the engineer's profile the viewer is entitled to.
+func (v *Viewer) Audience(e *Engineer) Audience {
+	switch {
+	case v.IsAdmin:
+		return AudienceAdmin
+	case v.Owns(e):
+		return AudienceOwner
+	case v.Contacts[e.ID]:
+		return AudienceContact
+	case v.IsMember:
+		return AudienceMember
+	case v.IsAuthenticated():
+		return AudienceAuthenticated
+	default:
+		return AudienceAnonymous
+	}
+}
+
+// Allows tells whether the field of the engineer is shown to the given audience, taking the
+// engineer's own settings into account for configurable fields.
+func (p FieldPolicy) Allows(field string, e *Engineer, audience Audience) bool {
+	if audience >= AudienceOwner {
+		return true
+	}
+
+	rule, ok := p[field]
+	if !ok {
+		return false
+	}
+
+	if rule.Follows != "" {
+		return p.Allows(rule.Follows, e, audience)
+	}
+
+	level := rule.Default
+	if override, ok := e.FieldVisibility[field]; ok && rule.Configurable {
+		level = override
+	}
+
+	required, ok := visibilityAudiences[level]
+	if !ok {
+		return false
+	}
+
+	return audience >= required
+}
+
+// AllowsFilter matches the engineers whose field the viewer may see, so that queries don't
+// select engineers on what they concealed. It is the query counterpart of Allows.
+func (p FieldPolicy) AllowsFilter(field string, v *Viewer) bson.M {
+	audience := v.Audience(&Engineer{})
+	if audience >= AudienceOwner {
+		return bson.M{}
+	}
+
+	alternatives := bson.A{}
+	if v.IsAuthenticated() {
+		alternatives = append(alternatives, bson.M{"user_id": v.UserID})
+	}
+
+	rule, ok := p[field]
+	if ok && rule.Follows != "" {
+		return p.AllowsFilter(rule.Follows, v)
+	}
+
+	if ok {
+		alternatives = append(alternatives, rule.levelFilter(field, audience))
+
+		if len(v.Contacts) > 0 && audience < AudienceContact {
+			contactIDs := bson.A{}
+			for engineerID := range v.Contacts {
+				contactIDs = append(contactIDs, engineerID)
+			}
+			alternatives = append(alternatives, bson.M{"$and": bson.A{
+				bson.M{"_id": bson.M{"$in": contactIDs}},
+				rule.levelFilter(field, AudienceContact),
+			}})
+		}
+	}
+
+	if len(alternatives) == 0 {
+		return bson.M{"_id": bson.M{"$in": bson.A{}}}
+	}
+
+	return bson.M{"$or": alternatives}
+}
+
+// levelFilter matches the engineers whose visibility level for the field is shown to the audience.
+func (r FieldRule) levelFilter(field string, audience Audience) bson.M {
+	levels := bson.A{}
+	for level, required := range visibilityAudiences {
+		if audience >= required {
+			levels = append(levels, level)
+		}
+	}
+
+	defaultAllowed := audience >= visibilityAudiences[r.Default]
+	if !r.Configurable {
+		if defaultAllowed {
+			return bson.M{}
+		}
+		return bson.M{"_id": bson.M{"$in": bson.A{}}}
+	}
+
+	setting := "field_visibility." + field
+	if !defaultAllowed {
+		return bson.M{setting: bson.M{"$in": levels}}
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{setting: bson.M{"$exists": false}},
+		bson.M{setting: bson.M{"$in": levels}},
+	}}
+}
+
+// AllowsByDefault tells whether the viewer sees the field of engineers who kept its default
+// visibility, for features that are only offered to those who can see a field.
+func (p FieldPolicy) AllowsByDefault(field string, v *Viewer) bool {
+	rule, ok := p[field]
+	if !ok {
+		return v.Audience(&Engineer{}) >= AudienceOwner
+	}
+	if rule.Follows != "" {
+		return p.AllowsByDefault(rule.Follows, v)
+	}
+	return v.Audience(&Engineer{}) >= visibilityAudiences[rule.Default]
+}
+
+// IsPublic tells whether the field is shown to everyone whatever the engineer's settings.
+func (p FieldPolicy) IsPublic(field string) bool {
+	rule, ok := p[field]
+	if !ok {
+		return false
+	}
+	if rule.Follows != "" {
+		return p.IsPublic(rule.Follows)
+	}
+	return !rule.Configurable && rule.Default == VisibilityPublic
+}
+
+// Validate checks visibility settings submitted by an engineer.
+func (p FieldPolicy) Validate(settings map[string]string) error {
+	for field, level := range settings {
+		rule, ok := p[field]
+		if !ok || !rule.Configurable {
+			return fmt.Errorf("visibility of field %s can't be changed", field)
+		}
+
+		if _, ok := visibilityAudiences[level]; !ok {
+			return fmt.Errorf("unknown visibility %q for field %s", level, field)
+		}
+	}
+
+	return nil
+}
+
+// EngineerView returns the engineer as the viewer is allowed to see it, keyed like the full
+// engineer JSON with concealed fields left out.
+func (v *Viewer) EngineerView(e *Engineer) (map[string]interface{}, error) {
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var view map[string]interface{}
+	err = json.Unmarshal(raw, &view)
+	if err != nil {
+		return nil, err
+	}
+
+	audience := v.Audience(e)
+	for field := range view {
+		if !EngineerFieldPolicy.Allows(field, e, audience) {
+			delete(view, field)
+		}
+	}
+
+	// stats cached for a previously linked account aren't the engineer's anymore
+	if e.GithubStats != nil && !strings.EqualFold(e.GithubStats.Login, GithubLogin(e.Github)) {
+		delete(view, "GithubStats")
+	}
+
+	return view, nil
+}
+
+// EngineerName is the part of the engineer's name the viewer may see, empty when all of it is
+// concealed.
+func (v *Viewer) EngineerName(e *Engineer) string {
+	audience := v.Audience(e)
+	names := []string{}
+	if EngineerFieldPolicy.Allows("Firstname", e, audience) && e.Firstname != "" {
+		names = append(names, e.Firstname)
+	}
+	if EngineerFieldPolicy.Allows("Lastname", e, audience) && e.Lastname != "" {
+		names = append(names, e.Lastname)
+	}
+	return strings.Join(names, " ")
+}
+
+// ConcealedEngineer is EngineerView as an engineer, with the concealed fields left empty, for
+// renderers that work on the struct rather than on JSON.
+func (v *Viewer) ConcealedEngineer(e *Engineer) (*Engineer, error) {
+	view, err := v.EngineerView(e)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(view)
+	if err != nil {
+		return nil, err
+	}
+
+	var concealed Engineer
+	err = json.Unmarshal(raw, &concealed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &concealed, nil
+}
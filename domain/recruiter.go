@@ -5,6 +5,7 @@ import (
 	"crypto/md5"
 	"errors"
 	"net/http"
+	"time"
 	"angular-talents-backend/db"
 	"angular-talents-backend/internal"
 
@@ -22,10 +23,19 @@ type Recruiter struct {
 	Company string			`bson:"company,omitempty"`
 	Role string				`bson:"role,required"`
 	Logo string				`bson:"logo,required"`
+	LogoImage *UploadedImage	`bson:"logo_image,omitempty"`
 	Bio string				`bson:"bio,required"`
 	LinkedIn string			`bson:"linkedin,required"`
 	Website string			`bson:"website,omitempty"`
 	IsMember bool			`bson:"is_member,required"`
+	HideViewActivity bool	`bson:"hide_view_activity,omitempty"`
+	Hidden *ProfileHide		`bson:"hidden,omitempty"`
+	Version int				`bson:"version,omitempty"`
+	CompletenessScore int	`bson:"completeness_score"`
+	CompletenessNudgedAt time.Time	`bson:"completeness_nudged_at,omitempty" json:"-"`
+	CompletenessNudges int	`bson:"completeness_nudges,omitempty" json:"-"`
+	CreatedAt time.Time		`bson:"created_at,omitempty"`
+	UpdatedAt time.Time		`bson:"updated_at,omitempty"`
 }
 
 type CreateRecruiterPayload struct {
@@ -33,7 +43,7 @@ type CreateRecruiterPayload struct {
 	LastName string		`json:"lastName"  validate:"required,alpha"`
 	Company string		`json:"company"  validate:"required"`
 	Role string			`json:"role"  validate:"required"`
-	Logo string			`json:"logo"  validate:"required"`
+	Logo string			`json:"logo,omitempty"  validate:"omitempty,url"`
 	Bio string			`json:"bio"  validate:"required"`
 	LinkedIn string		`json:"linkedIn"  validate:"required,url"`
 	Website string		`json:"website,omitempty"  validate:"omitempty,url"`
@@ -44,9 +54,15 @@ type UpdateRecruiterPayload struct {
 	LastName string		`bson:"last_name,omitempty" json:"lastName"  validate:"omitempty,alpha"`
 	Company string 		`bson:"company,omitempty" json:"company"  validate:"omitempty"`
 	Bio string			`bson:"bio,omitempty" json:"bio" validate:"omitempty"`
-	Logo string			`bson:"logo,omitempty" json:"logo" validate:"omitempty"`
+	Logo string			`bson:"logo,omitempty" json:"logo" validate:"omitempty,url"`
 	Role string			`bson:"role,omitempty" json:"role"  validate:"omitempty"`
 	Website string		`bson:"website,omitempty" json:"website,omitempty"  validate:"omitempty,url"`
+	HideViewActivity *bool	`bson:"hide_view_activity,omitempty" json:"hideViewActivity,omitempty"`
+	UpdatedAt time.Time	`bson:"updated_at,omitempty" json:"-"`
+}
+
+type UpdateMembershipPayload struct {
+	IsMember *bool		`json:"isMember" validate:"required"`
 }
 
 func (p *CreateRecruiterPayload) NewRecruiter(ctx context.Context) (*Recruiter, error) {
@@ -57,6 +73,7 @@ func (p *CreateRecruiterPayload) NewRecruiter(ctx context.Context) (*Recruiter,
 		return nil, internal.NewError(http.StatusInternalServerError, "recruiter.generate_id", "failed to generate id for new recruiter", err.Error())
 	}
 
+	now := time.Now()
 
 	 return &Recruiter{
 		ID: recruiterID,
@@ -70,6 +87,8 @@ func (p *CreateRecruiterPayload) NewRecruiter(ctx context.Context) (*Recruiter,
 		Website: p.Website,
 		LinkedIn: p.LinkedIn,
 		IsMember: false,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}, nil
 }
 
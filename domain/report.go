@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Why a profile is hidden: automatically, after enough reports, or by an admin acting on a flag.
+const (
+	ProfileHiddenByReports    = "reports"
+	ProfileHiddenByModeration = "moderation"
+)
+
+// ReportSettings tells when reported profiles are hidden: once HideThreshold users have reported
+// a profile and no admin has reviewed their reports yet, the profile is hidden for HideFor.
+type ReportSettings struct {
+	HideThreshold int
+	HideFor time.Duration
+}
+
+// ReportSettingsFromEnv reads REPORT_HIDE_THRESHOLD and REPORT_HIDE_DAYS, falling back to the
+// defaults when unset or invalid.
+func ReportSettingsFromEnv() ReportSettings {
+	return ReportSettings{
+		HideThreshold: envInt("REPORT_HIDE_THRESHOLD", 3),
+		HideFor: time.Duration(envInt("REPORT_HIDE_DAYS", 7)) * 24 * time.Hour,
+	}
+}
+
+// ProfileHide keeps a profile from everyone but its owner and admins until Until.
+type ProfileHide struct {
+	Reason string		`bson:"reason"`
+	At time.Time		`bson:"at"`
+	Until time.Time		`bson:"until"`
+}
+
+func NewProfileHide(reason string, now time.Time, hideFor time.Duration) *ProfileHide {
+	return &ProfileHide{Reason: reason, At: now, Until: now.Add(hideFor)}
+}
+
+// Active tells whether the profile is still hidden. A nil hide never is.
+func (h *ProfileHide) Active(now time.Time) bool {
+	return h != nil && now.Before(h.Until)
+}
+
+// NotHiddenFilter matches the profiles that aren't hidden, or no longer are.
+func NotHiddenFilter(now time.Time) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"hidden": bson.M{"$exists": false}},
+		bson.M{"hidden.until": bson.M{"$lte": now}},
+	}}
+}
+
+// Validate also checks that only acting on a flag hides the profile.
+func (p *ReviewModerationFlagPayload) Validate() error {
+	v := validator.New()
+	err := v.Struct(p)
+	if err != nil {
+		return err
+	}
+
+	if p.HideForDays > 0 && p.Status != ModerationFlagActioned {
+		return errors.New("only an actioned flag can hide the profile")
+	}
+
+	return nil
+}
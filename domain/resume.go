@@ -0,0 +1,304 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	resumeMargin       = 50.0
+	resumeContentWidth = PDFPageWidth - 2*resumeMargin
+	resumeBottom       = 70.0
+)
+
+var (
+	resumeBrand = PDFHexColor(0xDD0031)
+	resumeText  = PDFHexColor(0x222222)
+	resumeMuted = PDFHexColor(0x6B6B6B)
+	resumeRule  = PDFHexColor(0xDDDDDD)
+	resumeWhite = PDFHexColor(0xFFFFFF)
+)
+
+var roleTypeLabels = map[string]string{
+	"contract_part_time": "Contract, part-time",
+	"contract_full_time": "Contract, full-time",
+	"employee_part_time": "Employee, part-time",
+	"employee_full_time": "Employee, full-time",
+}
+
+var roleLevelLabels = map[string]string{
+	"junior":          "Junior",
+	"mid_level":       "Mid-level",
+	"senior":          "Senior",
+	"principal_staff": "Principal / Staff",
+	"c_level":         "C-level",
+}
+
+var searchStatusLabels = map[string]string{
+	SearchStatusActivelyLooking: "Actively looking",
+	SearchStatusOpen:            "Open to offers",
+	SearchStatusNotInterested:   "Not looking",
+}
+
+type resumeWriter struct {
+	doc  *PDFDocument
+	name string
+	y    float64
+}
+
+// RenderResume lays the engineer's profile out as a PDF. The engineer is expected to be
+// concealed for the viewer already: empty fields are simply left out. The note, when given, is
+// printed under the header, e.g. to tell non-members that some details are hidden.
+func RenderResume(e *Engineer, note string, now time.Time) []byte {
+	name := strings.TrimSpace(e.Firstname + " " + e.Lastname)
+	if name == "" {
+		name = "Angular engineer"
+	}
+
+	w := &resumeWriter{doc: NewPDFDocument(name+" - Resume", now), name: name}
+	w.firstPage(e.Tagline)
+
+	meta := []string{}
+	if location := joinNonEmpty(", ", e.City, e.State, e.Country); location != "" {
+		meta = append(meta, location)
+	}
+	if e.Timezone != "" {
+		meta = append(meta, e.Timezone)
+	}
+	if status := searchStatusLabels[e.SearchStatus]; status != "" {
+		meta = append(meta, status)
+	}
+	w.paragraph(PDFRegular, 10, resumeMuted, strings.Join(meta, "  ·  "))
+
+	if note != "" {
+		w.y -= 4
+		w.paragraph(PDFBold, 9, resumeBrand, note)
+	}
+
+	if e.Bio != "" {
+		w.heading("About")
+		w.paragraph(PDFRegular, 10, resumeText, e.Bio)
+	}
+
+	if len(e.RoleType) > 0 || len(e.RoleLevel) > 0 {
+		w.heading("Looking for")
+		if len(e.RoleLevel) > 0 {
+			w.labelled("Level", joinLabels(e.RoleLevel, roleLevelLabels))
+		}
+		if len(e.RoleType) > 0 {
+			w.labelled("Engagement", joinLabels(e.RoleType, roleTypeLabels))
+		}
+	}
+
+	links := [][2]string{
+		{"Website", e.Website},
+		{"GitHub", e.Github},
+		{"LinkedIn", e.LinkedIn},
+		{"Twitter", e.Twitter},
+		{"Stack Overflow", e.StackOverflow},
+	}
+	hasLinks := false
+	for _, link := range links {
+		if link[1] == "" {
+			continue
+		}
+		if !hasLinks {
+			w.heading("Links")
+			hasLinks = true
+		}
+		w.link(link[0], link[1])
+	}
+
+	if len(e.Experience) > 0 {
+		w.heading("Experience")
+		for _, entry := range e.Experience {
+			end := entry.EndDate
+			if entry.Current {
+				end = "present"
+			}
+			w.entry(entry.Title, entry.Company, resumeDates(entry.StartDate, end), entry.Description, entry.Technologies, "")
+		}
+	}
+
+	if len(e.Education) > 0 {
+		w.heading("Education")
+		for _, entry := range e.Education {
+			w.entry(entry.School, joinNonEmpty(", ", entry.Degree, entry.FieldOfStudy), resumeDates(entry.StartDate, entry.EndDate), entry.Description, nil, "")
+		}
+	}
+
+	if len(e.Projects) > 0 {
+		w.heading("Projects")
+		for _, project := range e.Projects {
+			link := project.URL
+			if link == "" {
+				link = project.Repo
+			}
+			w.entry(project.Name, "", "", project.Description, project.Technologies, link)
+		}
+	}
+
+	w.footers(now)
+	return w.doc.Bytes()
+}
+
+func (w *resumeWriter) firstPage(tagline string) {
+	w.doc.AddPage()
+
+	top := PDFPageHeight
+	w.doc.Rect(0, top-112, PDFPageWidth, 112, resumeBrand)
+	w.doc.Text(resumeMargin, top-32, PDFBold, 9, resumeWhite, "ANGULAR TALENTS")
+	w.doc.Text(resumeMargin, top-64, PDFBold, 24, resumeWhite, w.name)
+	if tagline != "" {
+		w.doc.Text(resumeMargin, top-88, PDFRegular, 12, resumeWhite, PDFWrapText(PDFRegular, 12, tagline, resumeContentWidth)[0])
+	}
+
+	w.y = top - 140
+}
+
+// newPage continues on a fresh page with a slim header repeating the engineer's name.
+func (w *resumeWriter) newPage() {
+	w.doc.AddPage()
+
+	top := PDFPageHeight
+	w.doc.Rect(0, top-8, PDFPageWidth, 8, resumeBrand)
+	w.doc.Text(resumeMargin, top-32, PDFBold, 10, resumeMuted, w.name)
+
+	w.y = top - 60
+}
+
+func (w *resumeWriter) ensureSpace(height float64) {
+	if w.y-height < resumeBottom {
+		w.newPage()
+	}
+}
+
+func (w *resumeWriter) heading(title string) {
+	w.ensureSpace(40)
+	w.y -= 18
+	w.doc.Text(resumeMargin, w.y, PDFBold, 12, resumeBrand, strings.ToUpper(title))
+	w.y -= 6
+	w.doc.Line(resumeMargin, w.y, resumeMargin+resumeContentWidth, w.y, 0.5, resumeRule)
+	w.y -= 8
+}
+
+func (w *resumeWriter) paragraph(font PDFFont, size float64, color PDFColor, text string) {
+	leading := size * 1.4
+	for _, line := range PDFWrapText(font, size, text, resumeContentWidth) {
+		w.ensureSpace(leading)
+		w.y -= leading
+		if line != "" {
+			w.doc.Text(resumeMargin, w.y, font, size, color, line)
+		}
+	}
+}
+
+func (w *resumeWriter) labelled(label, value string) {
+	w.ensureSpace(14)
+	w.y -= 14
+	w.doc.Text(resumeMargin, w.y, PDFBold, 10, resumeText, label)
+	w.doc.Text(resumeMargin+90, w.y, PDFRegular, 10, resumeText, value)
+}
+
+func (w *resumeWriter) link(label, uri string) {
+	w.labelled(label, uri)
+	w.doc.Link(resumeMargin+90, w.y-3, PDFTextWidth(PDFRegular, 10, uri), 13, uri)
+}
+
+// entry prints a history item: a bold title with the dates right-aligned, an optional subtitle,
+// the description and the technologies used.
+func (w *resumeWriter) entry(title, subtitle, dates, description string, technologies []string, uri string) {
+	w.ensureSpace(48)
+	w.y -= 18
+
+	datesWidth := PDFTextWidth(PDFRegular, 10, dates)
+	titleLines := PDFWrapText(PDFBold, 11, title, resumeContentWidth-datesWidth-12)
+	w.doc.Text(resumeMargin, w.y, PDFBold, 11, resumeText, titleLines[0])
+	if dates != "" {
+		w.doc.Text(resumeMargin+resumeContentWidth-datesWidth, w.y, PDFRegular, 10, resumeMuted, dates)
+	}
+	for _, line := range titleLines[1:] {
+		w.y -= 15
+		w.doc.Text(resumeMargin, w.y, PDFBold, 11, resumeText, line)
+	}
+
+	if subtitle != "" {
+		w.paragraph(PDFRegular, 10, resumeMuted, subtitle)
+	}
+
+	if uri != "" {
+		w.ensureSpace(14)
+		w.y -= 14
+		w.doc.Text(resumeMargin, w.y, PDFRegular, 10, resumeBrand, uri)
+		w.doc.Link(resumeMargin, w.y-3, PDFTextWidth(PDFRegular, 10, uri), 13, uri)
+	}
+
+	if description != "" {
+		w.y -= 2
+		w.paragraph(PDFRegular, 10, resumeText, description)
+	}
+
+	if len(technologies) > 0 {
+		w.paragraph(PDFRegular, 9, resumeMuted, strings.Join(technologies, " · "))
+	}
+}
+
+// footers are added last, once the number of pages is known.
+func (w *resumeWriter) footers(now time.Time) {
+	count := w.doc.PageCount()
+	site := strings.TrimPrefix(strings.TrimPrefix(FrontendURL(), "https://"), "http://")
+
+	for page := 1; page <= count; page++ {
+		w.doc.SetPage(page)
+		w.doc.Line(resumeMargin, 45, resumeMargin+resumeContentWidth, 45, 0.5, resumeRule)
+		w.doc.Text(resumeMargin, 32, PDFRegular, 8, resumeMuted, "Angular Talents  ·  "+site+"  ·  Generated "+now.Format("2 January 2006"))
+
+		pageLabel := "Page " + strconv.Itoa(page) + " of " + strconv.Itoa(count)
+		w.doc.Text(resumeMargin+resumeContentWidth-PDFTextWidth(PDFRegular, 8, pageLabel), 32, PDFRegular, 8, resumeMuted, pageLabel)
+	}
+}
+
+// resumeDates formats a YYYY-MM range as "Mar 2020 – present".
+func resumeDates(start, end string) string {
+	formatMonth := func(value string) string {
+		if value == "present" {
+			return "present"
+		}
+		parsed, err := time.Parse(monthLayout, value)
+		if err != nil {
+			return value
+		}
+		return parsed.Format("Jan 2006")
+	}
+
+	if start == "" {
+		return ""
+	}
+	if end == "" {
+		return formatMonth(start)
+	}
+	return formatMonth(start) + " – " + formatMonth(end)
+}
+
+func joinLabels(values []string, labels map[string]string) string {
+	named := make([]string, 0, len(values))
+	for _, value := range values {
+		if label, ok := labels[value]; ok {
+			named = append(named, label)
+		} else {
+			named = append(named, value)
+		}
+	}
+	return strings.Join(named, ", ")
+}
+
+func joinNonEmpty(separator string, values ...string) string {
+	nonEmpty := []string{}
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			nonEmpty = append(nonEmpty, strings.TrimSpace(value))
+		}
+	}
+	return strings.Join(nonEmpty, separator)
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SearchFrequencyDaily  = "daily"
+	SearchFrequencyWeekly = "weekly"
+)
+
+// SearchFrequencyPeriods is the time between two digests of a saved search.
+var SearchFrequencyPeriods = map[string]time.Duration{
+	SearchFrequencyDaily:  24 * time.Hour,
+	SearchFrequencyWeekly: 7 * 24 * time.Hour,
+}
+
+// MaxNotifiedEngineerIDs bounds how many notified engineers a saved search remembers, the oldest
+// being forgotten first. Only engineers updated since the previous digest are considered anyway.
+const MaxNotifiedEngineerIDs = 1000
+
+type SavedSearch struct {
+	ID uuid.UUID						`bson:"_id,required"`
+	RecruiterID uuid.UUID				`bson:"recruiter_id,required"`
+	Name string							`bson:"name,required"`
+	Filter *ListEngineersFilter			`bson:"filter,required"`
+	Frequency string					`bson:"frequency,required"`
+	LastRunAt time.Time					`bson:"last_run_at,required"`
+	NotifiedEngineerIDs []uuid.UUID		`bson:"notified_engineer_ids,omitempty" json:"-"`
+	CreatedAt time.Time					`bson:"created_at,required"`
+}
+
+// SavedSearchFilters mirrors the query parameters accepted by GET /engineers.
+type SavedSearchFilters struct {
+	Country string				`json:"country,omitempty"`
+	SearchStatus string			`json:"searchStatus,omitempty" validate:"omitempty,oneof=actively_looking open not_interested invisible"`
+	RoleLevel string			`json:"roleLevel,omitempty" validate:"omitempty,oneof=junior mid_level senior principal_staff c_level"`
+	RoleType string				`json:"roleType,omitempty" validate:"omitempty,oneof=contract_part_time contract_full_time employee_part_time employee_full_time"`
+	Near string					`json:"near,omitempty"`
+	Radius float64				`json:"radius,omitempty"`
+	Timezone string				`json:"timezone,omitempty"`
+	IncludeNotInterested bool	`json:"includeNotInterested,omitempty"`
+}
+
+type CreateSavedSearchPayload struct {
+	Name string						`json:"name" validate:"required,max=100"`
+	Frequency string				`json:"frequency" validate:"required,oneof=daily weekly"`
+	Filters SavedSearchFilters		`json:"filters"`
+}
+
+func (f *SavedSearchFilters) values() url.Values {
+	q := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+
+	set("country", f.Country)
+	set("searchStatus", f.SearchStatus)
+	set("roleLevel", f.RoleLevel)
+	set("roleType", f.RoleType)
+	set("near", f.Near)
+	set("timezone", f.Timezone)
+	if f.Radius != 0 {
+		q.Set("radius", strconv.FormatFloat(f.Radius, 'f', -1, 64))
+	}
+	if f.IncludeNotInterested {
+		q.Set("includeNotInterested", "true")
+	}
+
+	return q
+}
+
+// NewSavedSearch parses the filters exactly like the listing endpoint does, so that a saved
+// search always matches what the recruiter saw when saving it.
+func (p *CreateSavedSearchPayload) NewSavedSearch(ctx context.Context, recruiterID uuid.UUID) (*SavedSearch, error) {
+	params, err := NewListEngineerParams(ctx, true, p.Filters.values())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &SavedSearch{
+		ID: uuid.New(),
+		RecruiterID: recruiterID,
+		Name: p.Name,
+		Filter: params.Filter,
+		Frequency: p.Frequency,
+		LastRunAt: now,
+		CreatedAt: now,
+	}, nil
+}
+
+// WasNotified tells whether the engineer was already part of a digest for this search.
+func (s *SavedSearch) WasNotified(engineerID uuid.UUID) bool {
+	for _, id := range s.NotifiedEngineerIDs {
+		if id == engineerID {
+			return true
+		}
+	}
+	return false
+}
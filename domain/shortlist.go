@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PipelineStageSourced      = "sourced"
+	PipelineStageContacted    = "contacted"
+	PipelineStageInterviewing = "interviewing"
+	PipelineStageHired        = "hired"
+	PipelineStageRejected     = "rejected"
+)
+
+type Shortlist struct {
+	ID uuid.UUID					`bson:"_id,required"`
+	RecruiterID uuid.UUID			`bson:"recruiter_id,required"`
+	Name string						`bson:"name,required"`
+	Entries []ShortlistEntry		`bson:"entries"`
+	CreatedAt time.Time				`bson:"created_at,required"`
+	UpdatedAt time.Time				`bson:"updated_at,required"`
+}
+
+// ShortlistEntry is a candidate in a shortlist. Notes and tags are private to the recruiter.
+type ShortlistEntry struct {
+	EngineerID uuid.UUID			`bson:"engineer_id,required"`
+	Notes string					`bson:"notes,omitempty"`
+	Tags []string					`bson:"tags,omitempty"`
+	Stage string					`bson:"stage,required"`
+	AddedAt time.Time				`bson:"added_at,required"`
+	UpdatedAt time.Time				`bson:"updated_at,required"`
+}
+
+// HydratedShortlistEntry carries the engineer as the recruiter may see it, or nothing when the
+// engineer has since deleted or hidden the profile.
+type HydratedShortlistEntry struct {
+	ShortlistEntry
+	Engineer map[string]interface{}
+	Unavailable bool
+}
+
+type CreateShortlistPayload struct {
+	Name string			`json:"name" validate:"required,max=100"`
+}
+
+type UpdateShortlistPayload struct {
+	Name string			`json:"name" validate:"required,max=100"`
+}
+
+type AddShortlistEntryPayload struct {
+	EngineerID string	`json:"engineerId" validate:"required,uuid"`
+	Notes string		`json:"notes,omitempty" validate:"omitempty,max=5000"`
+	Tags []string		`json:"tags,omitempty" validate:"omitempty,max=20,dive,required,max=30"`
+	Stage string		`json:"stage,omitempty" validate:"omitempty,oneof=sourced contacted interviewing hired rejected"`
+}
+
+// UpdateShortlistEntryPayload only changes the fields that are sent; an empty tag list clears the tags.
+type UpdateShortlistEntryPayload struct {
+	Notes *string		`json:"notes,omitempty" validate:"omitempty,max=5000"`
+	Tags *[]string		`json:"tags,omitempty" validate:"omitempty,max=20,dive,required,max=30"`
+	Stage string		`json:"stage,omitempty" validate:"omitempty,oneof=sourced contacted interviewing hired rejected"`
+}
+
+func (p *CreateShortlistPayload) NewShortlist(recruiterID uuid.UUID) *Shortlist {
+	now := time.Now()
+	return &Shortlist{
+		ID: uuid.New(),
+		RecruiterID: recruiterID,
+		Name: p.Name,
+		Entries: []ShortlistEntry{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func (p *AddShortlistEntryPayload) NewShortlistEntry() (*ShortlistEntry, error) {
+	engineerID, err := uuid.Parse(p.EngineerID)
+	if err != nil {
+		return nil, err
+	}
+
+	stage := p.Stage
+	if stage == "" {
+		stage = PipelineStageSourced
+	}
+
+	now := time.Now()
+	return &ShortlistEntry{
+		EngineerID: engineerID,
+		Notes: p.Notes,
+		Tags: p.Tags,
+		Stage: stage,
+		AddedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (s *Shortlist) HasEngineer(engineerID uuid.UUID) bool {
+	for _, entry := range s.Entries {
+		if entry.EngineerID == engineerID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"encoding/xml"
+)
+
+// MaxSitemapURLs is the most URLs a sitemap file may hold, per the sitemaps protocol.
+const MaxSitemapURLs = 50000
+
+type sitemapURLSet struct {
+	XMLName xml.Name		`xml:"urlset"`
+	Xmlns string			`xml:"xmlns,attr"`
+	URLs []sitemapURL		`xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc string				`xml:"loc"`
+	LastMod string			`xml:"lastmod,omitempty"`
+}
+
+// NewEngineerSitemap lists the public pages of the engineers for search engines.
+func NewEngineerSitemap(engineers []*Engineer) ([]byte, error) {
+	set := sitemapURLSet{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9", URLs: []sitemapURL{}}
+	for _, engineer := range engineers {
+		url := sitemapURL{Loc: EngineerPublicURL(engineer)}
+		if !engineer.UpdatedAt.IsZero() {
+			url.LastMod = engineer.UpdatedAt.UTC().Format("2006-01-02")
+		}
+		set.URLs = append(set.URLs, url)
+	}
+
+	body, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(body, '\n')...), nil
+}
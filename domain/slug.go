@@ -0,0 +1,121 @@
+package domain
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MinSlugLength = 3
+	MaxSlugLength = 40
+	// MaxEngineerSlugs bounds the slugs an engineer holds, current and previous ones, so that
+	// nobody can squat names by changing theirs over and over.
+	MaxEngineerSlugs = 5
+)
+
+var (
+	ErrSlugTaken    = errors.New("slug is already taken")
+	ErrTooManySlugs = fmt.Errorf("an engineer can't claim more than %d slugs", MaxEngineerSlugs)
+)
+
+// profanityList is the default blocklist of the moderator, which slugs are checked against too.
+//
+//go:embed data/profanity.txt
+var profanityList string
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// reservedSlugs can't be claimed as they would read like pages of the site, or like us.
+var reservedSlugs = map[string]bool{
+	"about": true, "admin": true, "administrator": true, "angular": true, "angular-talents": true,
+	"angulartalents": true, "api": true, "blog": true, "contact": true, "dashboard": true,
+	"e": true, "engineer": true, "engineers": true, "help": true, "jobs": true, "login": true,
+	"logout": true, "me": true, "new": true, "pricing": true, "privacy": true, "profile": true,
+	"recruiter": true, "recruiters": true, "root": true, "settings": true, "sign-in": true,
+	"sign-up": true, "signup": true, "sitemap": true, "staff": true, "support": true,
+	"team": true, "terms": true, "www": true,
+}
+
+// EngineerSlug is a vanity name claimed by an engineer. Slugs stay with the engineer once
+// claimed, so links to a previous one keep leading to the profile.
+type EngineerSlug struct {
+	Slug string				`bson:"_id"`
+	EngineerID uuid.UUID	`bson:"engineer_id"`
+	ClaimedAt time.Time		`bson:"claimed_at"`
+}
+
+type ClaimSlugPayload struct {
+	Slug string		`json:"slug" validate:"required"`
+}
+
+// NormalizeSlug lowercases the slug and trims surrounding spaces and dashes.
+func NormalizeSlug(slug string) string {
+	return strings.Trim(strings.ToLower(strings.TrimSpace(slug)), "-")
+}
+
+// ValidateSlug checks a normalized slug: lowercase letters and digits in dash-separated words,
+// neither reserved nor offensive.
+func ValidateSlug(slug string) error {
+	if len(slug) < MinSlugLength || len(slug) > MaxSlugLength {
+		return fmt.Errorf("slug must be between %d and %d characters", MinSlugLength, MaxSlugLength)
+	}
+
+	if !slugPattern.MatchString(slug) {
+		return errors.New("slug may only contain lowercase letters, digits and single dashes between them")
+	}
+
+	if _, err := uuid.Parse(slug); err == nil || reservedSlugs[slug] {
+		return errors.New("slug is reserved")
+	}
+
+	if containsProfanity(slug) {
+		return errors.New("slug contains inappropriate language")
+	}
+
+	return nil
+}
+
+// containsProfanity matches listed words against each word of the slug, and against runs of
+// one- or two-letter words joined together, which catches "f-u-c-k" without flagging words that
+// merely contain a listed one, like "scunthorpe".
+func containsProfanity(slug string) bool {
+	profaneWords := CurrentModerator().Blocklist.words
+
+	run := ""
+	for _, word := range append(strings.Split(slug, "-"), "") {
+		if profaneWords[word] {
+			return true
+		}
+
+		if word != "" && len(word) <= 2 {
+			run += word
+			continue
+		}
+
+		if len(run) > 2 {
+			for listed := range profaneWords {
+				if strings.Contains(run, listed) {
+					return true
+				}
+			}
+		}
+		run = ""
+	}
+
+	return false
+}
+
+// EngineerPublicURL is the address of the engineer's public page on the site, under their slug
+// when they claimed one.
+func EngineerPublicURL(e *Engineer) string {
+	if e.Slug != "" {
+		return FrontendURL() + "/e/" + e.Slug
+	}
+	return FrontendURL() + "/engineers/" + e.ID.String()
+}
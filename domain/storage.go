@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const defaultUploadsURL = "http://localhost:3000/uploads"
+
+var ErrInvalidBlobKey = errors.New("invalid blob key")
+
+// BlobStorage keeps uploaded files. Put returns the public URL the file is served from, and
+// Delete succeeds when the file is already gone.
+type BlobStorage interface {
+	Put(ctx context.Context, key, contentType string, data []byte) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+var blobStorage BlobStorage
+
+func SetBlobStorage(s BlobStorage) {
+	blobStorage = s
+}
+
+func CurrentBlobStorage() BlobStorage {
+	if blobStorage == nil {
+		blobStorage = NewBlobStorageFromEnv()
+	}
+	return blobStorage
+}
+
+// NewBlobStorageFromEnv picks the storage configured by STORAGE. Files are kept on the local disk
+// by default; "s3" uses any S3-compatible service such as AWS S3 or MinIO.
+func NewBlobStorageFromEnv() BlobStorage {
+	switch os.Getenv("STORAGE") {
+	case "s3":
+		return NewS3BlobStorage(S3Config{
+			Endpoint: os.Getenv("S3_ENDPOINT"),
+			Region: os.Getenv("S3_REGION"),
+			Bucket: os.Getenv("S3_BUCKET"),
+			AccessKeyID: os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PublicURL: os.Getenv("S3_PUBLIC_URL"),
+		})
+	default:
+		return NewLocalBlobStorage(os.Getenv("UPLOADS_DIR"), os.Getenv("UPLOADS_URL"))
+	}
+}
+
+// LocalBlobStorage writes files below a directory and serves them itself, which suits a single
+// instance with a persistent disk.
+type LocalBlobStorage struct {
+	Dir     string
+	BaseURL string
+}
+
+func NewLocalBlobStorage(dir, baseURL string) *LocalBlobStorage {
+	if dir == "" {
+		dir = "uploads"
+	}
+	if baseURL == "" {
+		baseURL = defaultUploadsURL
+	}
+
+	return &LocalBlobStorage{
+		Dir:     dir,
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (s *LocalBlobStorage) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(filepath.Dir(filename), 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	// written aside and renamed so that a file is never served half-written
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	err = os.Chmod(tmp.Name(), 0o644)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.Rename(tmp.Name(), filename)
+	if err != nil {
+		return "", err
+	}
+
+	return s.BaseURL + "/" + key, nil
+}
+
+func (s *LocalBlobStorage) Delete(ctx context.Context, key string) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filename)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// PathPrefix is the path of the base URL, which the router serves the files under.
+func (s *LocalBlobStorage) PathPrefix() string {
+	parsed, err := url.Parse(s.BaseURL)
+	if err != nil || parsed.Path == "" {
+		return "/uploads/"
+	}
+	return strings.TrimSuffix(parsed.Path, "/") + "/"
+}
+
+// Handler serves the stored files, without directory listings.
+func (s *LocalBlobStorage) Handler() http.Handler {
+	files := http.StripPrefix(s.PathPrefix(), http.FileServer(http.Dir(s.Dir)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		files.ServeHTTP(w, r)
+	})
+}
+
+func (s *LocalBlobStorage) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || cleaned != "/"+key {
+		return "", ErrInvalidBlobKey
+	}
+
+	return filepath.Join(s.Dir, filepath.FromSlash(cleaned)), nil
+}
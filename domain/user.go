@@ -4,8 +4,10 @@ import (
 	"angular-talents-backend/db"
 	"context"
 	"crypto/md5"
+	_ "embed"
 	"errors"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -18,9 +20,11 @@ import (
 type User struct {
 	ID       uuid.UUID `bson:"_id,required"`
 	Email    string    `bson:"email,required"`
+	NormalizedEmail string	`bson:"normalized_email,omitempty"`
 	Password string    `bson:"password,required"`
 	Verified bool			`bson:"verified,omitempty"`
 	VerificationCode int	`bson:"verificationCode,omitempty"`
+	IsAdmin bool			`bson:"is_admin,omitempty"`
 }
 
 type BodyData struct {
@@ -44,9 +48,62 @@ type JwtCustomClaims struct {
 
 var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
 
+//go:embed data/disposable-domains.txt
+var disposableDomainList string
+
+var disposableDomains = parseWordList(disposableDomainList).words
+
+// dotlessProviders ignore dots in the local part of addresses, and aliasedProviders are other
+// domains of the same mailboxes.
+var (
+	dotlessProviders = map[string]bool{"gmail.com": true}
+	aliasedProviders = map[string]string{"googlemail.com": "gmail.com"}
+)
+
+// NormalizeEmail returns the mailbox the address delivers to, so that aliases of an address, like
+// "Jane.Doe+jobs@googlemail.com" for "janedoe@gmail.com", normalize the same. It lowercases the
+// address and drops the "+tag" of plus-addressing, as well as dots for providers ignoring them.
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at == -1 {
+		return email
+	}
+
+	local, domain := email[:at], email[at+1:]
+	if alias, ok := aliasedProviders[domain]; ok {
+		domain = alias
+	}
+
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+
+	if dotlessProviders[domain] {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+
+	return local + "@" + domain
+}
+
+// IsDisposableEmail tells whether the address is from a disposable email provider, or a
+// subdomain of one.
+func IsDisposableEmail(email string) bool {
+	labels := strings.Split(EmailDomain(email), ".")
+	for i := 0; i < len(labels)-1; i++ {
+		if disposableDomains[strings.Join(labels[i:], ".")] {
+			return true
+		}
+	}
+	return false
+}
+
+// NewUser derives the ID of the user from their normalized email, so that the aliases of an
+// address can't sign up again.
 func (d *SignUpData) NewUser() (*User, error) {
 	newUser := &User{
-		Email: d.Email,
+		Email: strings.TrimSpace(d.Email),
+		NormalizedEmail: NormalizeEmail(d.Email),
 	}
 
 	err := newUser.generateID()
@@ -72,7 +129,7 @@ func (u *User) hashPassword(givenPassword string) error {
 }
 
 func (u *User) generateID() error {
-	userHash := md5.Sum([]byte(u.Email))
+	userHash := md5.Sum([]byte(u.NormalizedEmail))
 	userID, err := uuid.FromBytes(userHash[:])
 	if err != nil {
 		return err
@@ -82,6 +139,10 @@ func (u *User) generateID() error {
 }
 
 func (u *User) Validate(ctx context.Context) error {
+	if IsDisposableEmail(u.Email) {
+		return errors.New("disposable email addresses are not accepted")
+	}
+
 	alreadyCreated, err := u.checkAlreadyCreated(ctx)
 	if err != nil {
 		return err
@@ -96,7 +157,11 @@ func (u *User) Validate(ctx context.Context) error {
 
 func (u *User) checkAlreadyCreated(ctx context.Context) (bool, error) {
 	userCol := db.Database.Collection("users")
-	filter := bson.D{{Key: "email", Value: u.Email}}
+	filter := bson.M{"$or": bson.A{
+		bson.M{"email": u.Email},
+		bson.M{"normalized_email": u.NormalizedEmail},
+		bson.M{"_id": u.ID},
+	}}
 	count, err := userCol.CountDocuments(ctx, filter)
 	if err != nil {
 		return false, err
@@ -176,11 +241,16 @@ func ValidateToken(signedToken string) (uuid.UUID, error) {
 	return claims.UserID, nil
 }
 
+// checkExists finds the user by the address they signed up with, or else by any alias of it.
 func (ld *LoginData) checkExists(ctx context.Context) (*User, error) {
 	var user User
 	useCol := db.Database.Collection("users")
 	filter := bson.D{{Key: "email", Value: ld.Email}}
 	err := useCol.FindOne(ctx, filter).Decode(&user)
+	if err == mongo.ErrNoDocuments {
+		filter = bson.D{{Key: "normalized_email", Value: NormalizeEmail(ld.Email)}}
+		err = useCol.FindOne(ctx, filter).Decode(&user)
+	}
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, errors.New("user not found")
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	SearchStatusActivelyLooking = "actively_looking"
+	SearchStatusOpen            = "open"
+	SearchStatusNotInterested   = "not_interested"
+	SearchStatusInvisible       = "invisible"
+)
+
+// Viewer is whoever is reading engineer data: anonymous visitors have a nil UserID. Contacts
+// holds the engineers who accepted a contact request from the viewing recruiter. Company and
+// EmailDomain identify the viewer's employer for the engineers' blocklists.
+type Viewer struct {
+	UserID      uuid.UUID
+	IsMember    bool
+	IsAdmin     bool
+	Contacts    map[uuid.UUID]bool
+	Company     string
+	EmailDomain string
+}
+
+// NewViewer builds the viewer from the values set by the auth and membership middlewares.
+func NewViewer(ctx context.Context) *Viewer {
+	viewer := &Viewer{}
+
+	if userID, ok := ctx.Value("userID").(uuid.UUID); ok {
+		viewer.UserID = userID
+	}
+
+	if isMember, ok := ctx.Value("isMember").(bool); ok {
+		viewer.IsMember = isMember
+	}
+
+	if isAdmin, ok := ctx.Value("isAdmin").(bool); ok {
+		viewer.IsAdmin = isAdmin
+	}
+
+	if recruiter, ok := ctx.Value("recruiter").(*Recruiter); ok {
+		viewer.Company = recruiter.Company
+	}
+
+	if email, ok := ctx.Value("userEmail").(string); ok {
+		viewer.EmailDomain = EmailDomain(email)
+	}
+
+	if contactEngineerIDs, ok := ctx.Value("contactEngineerIDs").([]uuid.UUID); ok {
+		viewer.Contacts = map[uuid.UUID]bool{}
+		for _, engineerID := range contactEngineerIDs {
+			viewer.Contacts[engineerID] = true
+		}
+	}
+
+	return viewer
+}
+
+func (v *Viewer) IsAuthenticated() bool {
+	return v.UserID != uuid.Nil
+}
+
+func (v *Viewer) Owns(e *Engineer) bool {
+	return v.IsAuthenticated() && e.UserID == v.UserID
+}
+
+// UpgradeRequired tells whether the viewer only gets concealed profiles until they become a member.
+func (v *Viewer) UpgradeRequired() bool {
+	return !v.IsMember && !v.IsAdmin
+}
+
+// CanSeeEngineer tells whether the engineer may be returned at all, regardless of which fields
+// are concealed. Invisible and hidden profiles only exist for their owner and admins, and
+// engineers are hidden from the companies they blocked.
+func (v *Viewer) CanSeeEngineer(e *Engineer) bool {
+	if v.IsAdmin || v.Owns(e) {
+		return true
+	}
+
+	return e.SearchStatus != SearchStatusInvisible && !e.Hidden.Active(time.Now()) && !v.IsBlockedBy(e)
+}
+
+// EngineerListingFilter restricts listings to the profiles the viewer may see. Engineers who are
+// not interested are left out unless the viewer asks for them, either by filtering on that status
+// or with includeNotInterested.
+func (v *Viewer) EngineerListingFilter(requestedStatus string, includeNotInterested bool) bson.M {
+	filter := v.searchStatusFilter(requestedStatus, includeNotInterested)
+	if v.IsAdmin {
+		return filter
+	}
+
+	clauses := bson.A{filter, v.ownedOr(NotHiddenFilter(time.Now()))}
+	if keys := v.employerKeys(); len(keys) > 0 {
+		clauses = append(clauses, v.ownedOr(bson.M{"blocked_companies": bson.M{"$nin": keys}}))
+	}
+
+	return bson.M{"$and": clauses}
+}
+
+// ownedOr extends the filter to the viewer's own profile.
+func (v *Viewer) ownedOr(filter bson.M) bson.M {
+	if !v.IsAuthenticated() {
+		return filter
+	}
+	return bson.M{"$or": bson.A{filter, bson.M{"user_id": v.UserID}}}
+}
+
+func (v *Viewer) searchStatusFilter(requestedStatus string, includeNotInterested bool) bson.M {
+	switch requestedStatus {
+	case SearchStatusInvisible:
+		if v.IsAdmin {
+			return bson.M{"search_status": SearchStatusInvisible}
+		}
+		return bson.M{"search_status": SearchStatusInvisible, "user_id": v.UserID}
+	case "":
+	default:
+		return bson.M{"search_status": requestedStatus}
+	}
+
+	hidden := bson.A{}
+	if !v.IsAdmin {
+		hidden = append(hidden, SearchStatusInvisible)
+	}
+	if !includeNotInterested {
+		hidden = append(hidden, SearchStatusNotInterested)
+	}
+
+	if len(hidden) == 0 {
+		return bson.M{}
+	}
+
+	filter := bson.M{"search_status": bson.M{"$nin": hidden}}
+	if !v.IsAuthenticated() {
+		return filter
+	}
+
+	return bson.M{"$or": bson.A{filter, bson.M{"user_id": v.UserID}}}
+}
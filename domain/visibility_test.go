@@ -0,0 +1,269 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestViewerCanSeeEngineer(t *testing.T) {
+	ownerID := uuid.New()
+	otherID := uuid.New()
+
+	tests := []struct {
+		name         string
+		viewer       *Viewer
+		searchStatus string
+		want         bool
+	}{
+		{"anonymous sees open", &Viewer{}, SearchStatusOpen, true},
+		{"anonymous sees not interested", &Viewer{}, SearchStatusNotInterested, true},
+		{"anonymous doesn't see invisible", &Viewer{}, SearchStatusInvisible, false},
+		{"member doesn't see invisible", &Viewer{UserID: otherID, IsMember: true}, SearchStatusInvisible, false},
+		{"member sees not interested", &Viewer{UserID: otherID, IsMember: true}, SearchStatusNotInterested, true},
+		{"owner sees own invisible", &Viewer{UserID: ownerID}, SearchStatusInvisible, true},
+		{"owner sees own not interested", &Viewer{UserID: ownerID}, SearchStatusNotInterested, true},
+		{"admin sees invisible", &Viewer{UserID: otherID, IsAdmin: true}, SearchStatusInvisible, true},
+		{"admin sees not interested", &Viewer{UserID: otherID, IsAdmin: true}, SearchStatusNotInterested, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engineer := &Engineer{UserID: ownerID, SearchStatus: tt.searchStatus}
+			if got := tt.viewer.CanSeeEngineer(engineer); got != tt.want {
+				t.Errorf("CanSeeEngineer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewerCanSeeHiddenEngineer(t *testing.T) {
+	ownerID := uuid.New()
+	hidden := &Engineer{
+		UserID: ownerID,
+		SearchStatus: SearchStatusOpen,
+		Hidden: &ProfileHide{Reason: ProfileHiddenByReports, At: time.Now().Add(-time.Hour), Until: time.Now().Add(time.Hour)},
+	}
+
+	tests := []struct {
+		name   string
+		viewer *Viewer
+		want   bool
+	}{
+		{"anonymous", &Viewer{}, false},
+		{"member", &Viewer{UserID: uuid.New(), IsMember: true}, false},
+		{"owner", &Viewer{UserID: ownerID}, true},
+		{"admin", &Viewer{UserID: uuid.New(), IsAdmin: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.viewer.CanSeeEngineer(hidden); got != tt.want {
+				t.Errorf("CanSeeEngineer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewerEngineerListingFilter(t *testing.T) {
+	ownerID := uuid.New()
+	otherID := uuid.New()
+
+	engineer := func(searchStatus string) bson.M {
+		return bson.M{"user_id": ownerID, "search_status": searchStatus}
+	}
+
+	tests := []struct {
+		name                 string
+		viewer               *Viewer
+		requestedStatus      string
+		includeNotInterested bool
+		engineer             bson.M
+		want                 bool
+	}{
+		{"anonymous lists open", &Viewer{}, "", false, engineer(SearchStatusOpen), true},
+		{"anonymous doesn't list invisible", &Viewer{}, "", false, engineer(SearchStatusInvisible), false},
+		{"anonymous doesn't list not interested by default", &Viewer{}, "", false, engineer(SearchStatusNotInterested), false},
+		{"anonymous lists not interested on request", &Viewer{}, "", true, engineer(SearchStatusNotInterested), true},
+		{"anonymous lists not interested when filtering on it", &Viewer{}, SearchStatusNotInterested, false, engineer(SearchStatusNotInterested), true},
+		{"anonymous doesn't list invisible when filtering on it", &Viewer{}, SearchStatusInvisible, false, engineer(SearchStatusInvisible), false},
+		{"member doesn't list invisible", &Viewer{UserID: otherID, IsMember: true}, "", true, engineer(SearchStatusInvisible), false},
+		{"owner lists own invisible", &Viewer{UserID: ownerID}, "", false, engineer(SearchStatusInvisible), true},
+		{"owner lists own not interested", &Viewer{UserID: ownerID}, "", false, engineer(SearchStatusNotInterested), true},
+		{"owner lists own invisible when filtering on it", &Viewer{UserID: ownerID}, SearchStatusInvisible, false, engineer(SearchStatusInvisible), true},
+		{"other user doesn't list invisible when filtering on it", &Viewer{UserID: otherID}, SearchStatusInvisible, false, engineer(SearchStatusInvisible), false},
+		{"admin lists invisible", &Viewer{UserID: otherID, IsAdmin: true}, "", false, engineer(SearchStatusInvisible), true},
+		{"admin doesn't list not interested by default", &Viewer{UserID: otherID, IsAdmin: true}, "", false, engineer(SearchStatusNotInterested), false},
+		{"admin lists not interested on request", &Viewer{UserID: otherID, IsAdmin: true}, "", true, engineer(SearchStatusNotInterested), true},
+		{"admin lists invisible when filtering on it", &Viewer{UserID: otherID, IsAdmin: true}, SearchStatusInvisible, false, engineer(SearchStatusInvisible), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.viewer.EngineerListingFilter(tt.requestedStatus, tt.includeNotInterested)
+			if got := matchesFilter(tt.engineer, filter); got != tt.want {
+				t.Errorf("filter %v matched = %v, want %v", filter, got, tt.want)
+			}
+		})
+	}
+}
+
+// matchesFilter evaluates the subset of the MongoDB query language the listing filters use
+// against a document.
+func matchesFilter(doc bson.M, filter bson.M) bool {
+	for key, condition := range filter {
+		switch key {
+		case "$and":
+			for _, clause := range condition.(bson.A) {
+				if !matchesFilter(doc, clause.(bson.M)) {
+					return false
+				}
+			}
+		case "$or":
+			matched := false
+			for _, clause := range condition.(bson.A) {
+				if matchesFilter(doc, clause.(bson.M)) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		default:
+			value, exists := lookupField(doc, key)
+			if !matchesCondition(value, exists, condition) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func lookupField(doc bson.M, path string) (interface{}, bool) {
+	var current interface{} = doc
+	for _, part := range strings.Split(path, ".") {
+		nested, ok := current.(bson.M)
+		if !ok {
+			return nil, false
+		}
+		current, ok = nested[part]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func matchesCondition(value interface{}, exists bool, condition interface{}) bool {
+	operators, ok := condition.(bson.M)
+	if !ok {
+		return exists && reflect.DeepEqual(value, condition)
+	}
+
+	for operator, operand := range operators {
+		switch operator {
+		case "$exists":
+			if exists != operand.(bool) {
+				return false
+			}
+		case "$nin":
+			excluded := reflect.ValueOf(operand)
+			for i := 0; i < excluded.Len(); i++ {
+				if exists && containsValue(value, excluded.Index(i).Interface()) {
+					return false
+				}
+			}
+		case "$in":
+			included := reflect.ValueOf(operand)
+			found := false
+			for i := 0; i < included.Len(); i++ {
+				if exists && containsValue(value, included.Index(i).Interface()) {
+					found = true
+				}
+			}
+			if !found {
+				return false
+			}
+		case "$lte":
+			at, ok := value.(time.Time)
+			if !exists || !ok || at.After(operand.(time.Time)) {
+				return false
+			}
+		case "$gte":
+			number, ok := value.(int)
+			if !exists || !ok || number < operand.(int) {
+				return false
+			}
+		default:
+			panic("unsupported operator " + operator)
+		}
+	}
+
+	return true
+}
+
+// containsValue compares like MongoDB does, matching arrays on any of their elements.
+func containsValue(value, wanted interface{}) bool {
+	if reflect.DeepEqual(value, wanted) {
+		return true
+	}
+
+	items := reflect.ValueOf(value)
+	if items.Kind() != reflect.Slice {
+		return false
+	}
+	for i := 0; i < items.Len(); i++ {
+		if reflect.DeepEqual(items.Index(i).Interface(), wanted) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestViewerEngineerViewContactDetails(t *testing.T) {
+	ownerID := uuid.New()
+	engineer := &Engineer{
+		ID: uuid.New(),
+		UserID: ownerID,
+		Github: "https://github.com/ngtalents-demo",
+		LinkedIn: "https://www.linkedin.com/in/ngtalents-demo",
+		Twitter: "https://twitter.com/ngtalents-demo",
+		StackOverflow: "https://stackoverflow.com/users/1/ngtalents-demo",
+		Website: "https://ngtalents.example.com",
+	}
+
+	tests := []struct {
+		name   string
+		viewer *Viewer
+		want   bool
+	}{
+		{"anonymous", &Viewer{}, false},
+		{"member", &Viewer{UserID: uuid.New(), IsMember: true}, false},
+		{"contact", &Viewer{UserID: uuid.New(), IsMember: true, Contacts: map[uuid.UUID]bool{engineer.ID: true}}, true},
+		{"owner", &Viewer{UserID: ownerID}, true},
+		{"admin", &Viewer{UserID: uuid.New(), IsAdmin: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := tt.viewer.EngineerView(engineer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, field := range []string{"LinkedIn", "Twitter", "StackOverflow", "Website"} {
+				if _, got := view[field]; got != tt.want {
+					t.Errorf("EngineerView() shows %s = %v, want %v", field, got, tt.want)
+				}
+			}
+		})
+	}
+}
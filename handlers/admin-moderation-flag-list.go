@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+)
+
+func HandleAdminModerationFlagList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	internal.LogInfo("Starting moderation flag list", map[string]interface{}{"user_id": r.Context().Value("userID")})
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = domain.ModerationFlagOpen
+	}
+
+	if status != domain.ModerationFlagOpen && status != domain.ModerationFlagDismissed && status != domain.ModerationFlagActioned {
+		return internal.NewError(http.StatusBadRequest, "admin.moderation.flag.list.parse_status", "failed to list moderation flags", "status must be one of open, dismissed or actioned")
+	}
+
+	pagination, err := domain.NewPagination(r.URL.Query(), 20, 100)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "admin.moderation.flag.list.parse_pagination", "failed to list moderation flags", err.Error())
+	}
+
+	flags, err := dao.ReadModerationFlags(r.Context(), status, pagination)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "admin.moderation.flag.list.read_flags", "failed to list moderation flags", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed moderation flags", map[string]interface{}{"user_id": r.Context().Value("userID"), "status": status})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"flags": flags, "pagination": pagination})
+	return nil
+}
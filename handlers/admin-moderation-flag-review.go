@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleAdminModerationFlagReview(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	var reviewPayload domain.ReviewModerationFlagPayload
+
+	internal.LogInfo("Starting moderation flag review", map[string]interface{}{"user_id": userID, "flag_id": mux.Vars(r.Request)["flagID"]})
+
+	flagID, err := uuid.Parse(mux.Vars(r.Request)["flagID"])
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "admin.moderation.flag.review.parse_id", "failed to review moderation flag", err.Error())
+	}
+
+	err = r.DecodeJSON(&w, &reviewPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "admin.moderation.flag.review.decode_body", "failed to review moderation flag", err.Error())
+	}
+
+	err = reviewPayload.Validate()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "admin.moderation.flag.review.validate_body", "failed to review moderation flag", err.Error())
+	}
+
+	now := time.Now()
+	flag, err := dao.ReviewModerationFlag(r.Context(), flagID, userID, &reviewPayload, now)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "admin.moderation.flag.review.update_table", "failed to review moderation flag", err.Error())
+	}
+
+	if flag == nil {
+		return internal.NewError(http.StatusNotFound, "admin.moderation.flag.review.update_table", "failed to review moderation flag", "open flag not found")
+	}
+
+	reports := []*domain.ModerationFlag{}
+	if flag.Source == domain.ModerationSourceReport {
+		otherReports, err := dao.ReviewOpenReports(r.Context(), flag.ProfileID, userID, &reviewPayload, now)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "admin.moderation.flag.review.update_reports", "failed to review moderation flag", err.Error())
+		}
+		reports = append([]*domain.ModerationFlag{flag}, otherReports...)
+	}
+
+	if reviewPayload.HideForDays > 0 {
+		hide := domain.NewProfileHide(domain.ProfileHiddenByModeration, now, time.Duration(reviewPayload.HideForDays) * 24 * time.Hour)
+		err = dao.HideProfile(r.Context(), flag.ProfileType, flag.ProfileID, hide)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "admin.moderation.flag.review.hide_profile", "failed to review moderation flag", err.Error())
+		}
+	} else if len(reports) > 0 {
+		// the reports that hid the profile are all reviewed, whether dismissed or acted on otherwise
+		err = dao.UnhideProfile(r.Context(), flag.ProfileType, flag.ProfileID, domain.ProfileHiddenByReports)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "admin.moderation.flag.review.unhide_profile", "failed to review moderation flag", err.Error())
+		}
+	}
+
+	for _, report := range reports {
+		notifyUser(r.Context(), *report.ReporterID, domain.NotificationReportOutcome, map[string]interface{}{
+			"report_id": report.ID,
+			"profile_type": report.ProfileType,
+			"profile_id": report.ProfileID,
+			"outcome": report.Status,
+		})
+	}
+
+	internal.LogInfo("Successfully reviewed moderation flag", map[string]interface{}{"user_id": userID, "flag_id": flag.ID, "status": flag.Status, "reports": len(reports)})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"flag": flag, "reports": reports})
+	return nil
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+func HandleAdminRecruiterMembershipUpdate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiterID := mux.Vars(r.Request)["recruiterID"]
+	var membershipPayload domain.UpdateMembershipPayload
+
+	internal.LogInfo("Starting recruiter membership update", map[string]interface{}{"user_id": r.Context().Value("userID"), "recruiter_id": recruiterID})
+
+	err := r.DecodeJSON(&w, &membershipPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "admin.recruiter.membership.decode_body", "failed to update membership", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(membershipPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "admin.recruiter.membership.validate_body", "failed to update membership", err.Error())
+	}
+
+	recruiter, err := dao.UpdateRecruiterMembership(r.Context(), recruiterID, *membershipPayload.IsMember)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "admin.recruiter.membership.update_table", "failed to update membership", err.Error())
+	}
+
+	if recruiter == nil {
+		return internal.NewError(http.StatusNotFound, "admin.recruiter.membership.update_table", "failed to update membership", "recruiter not found")
+	}
+
+	notifyUser(r.Context(), recruiter.UserID, domain.NotificationMembershipChange, map[string]interface{}{
+		"is_member": recruiter.IsMember,
+	})
+
+	internal.LogInfo("Successfully updated recruiter membership", map[string]interface{}{"user_id": r.Context().Value("userID"), "recruiter_id": recruiter.ID, "is_member": recruiter.IsMember})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Recruiter{"recruiter": recruiter})
+	return nil
+}
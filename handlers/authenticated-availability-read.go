@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleAuthenticatedAvailabilityRead(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting authenticated availability read", map[string]interface{}{"user_id": userID})
+
+	engineer, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "authenticated_availability.read.read_engineer", "failed to read availability", err.Error())
+	}
+
+	if engineer == nil {
+		return internal.NewError(http.StatusNotFound, "authenticated_availability.read.read_engineer", "failed to read availability", "engineer not found")
+	}
+
+	availability, err := dao.FindAvailability(r.Context(), engineer.ID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "authenticated_availability.read.read_availability", "failed to read availability", err.Error())
+	}
+
+	internal.LogInfo("Successfully read authenticated availability", map[string]interface{}{"user_id": userID, "engineer_id": engineer.ID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Availability{"availability": availability})
+	return nil
+}
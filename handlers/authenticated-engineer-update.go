@@ -6,7 +6,6 @@ import (
 	"angular-talents-backend/domain"
 	"angular-talents-backend/internal"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
@@ -21,17 +20,50 @@ func HandleAuthenticatedEngineerUpdate(w internal.EnhancedResponseWriter, r *int
 		return internal.NewError(http.StatusInternalServerError, "authenticated_engineer.update.decode_body", "failed to update engineer", err.Error())
 	}
 
-	v := validator.New()
-	err = v.Struct(engPayload)
+	err = engPayload.ValidateFields()
 	if err != nil {
 		return internal.NewError(http.StatusBadRequest, "authenticated_engineer.udpate.validate", "failed to update engineer", err.Error())
 	}
 
+	moderation := domain.CurrentModerator().Moderate(engPayload.ModerationContent())
+	err = moderation.Err()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "authenticated_engineer.update.moderate", "failed to update engineer", err.Error())
+	}
+
+	currentEng, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "authenticated_engineer.update.read_engineer", "failed to update engineer", err.Error())
+	}
+
+	if currentEng == nil {
+		return internal.NewError(http.StatusNotFound, "authenticated_engineer.update.read_engineer", "failed to update engineer", "engineer not found")
+	}
+
+	err = engPayload.Geocode(r.Context(), currentEng)
+	if err != nil {
+		internal.LogInfo("Failed to geocode engineer location", map[string]interface{}{"user_id": userID, "error": err.Error()})
+	}
+
+	engPayload.NormalizeBlockedCompanies()
+	engPayload.ClearStaleGithubStats(currentEng)
+
 	updatedEng, err := dao.UpdateEngineerByUser(r.Context(), userID , &engPayload)
 	if err != nil {
 		return internal.NewError(http.StatusInternalServerError, "authenticated_engineer.update.update_table", "failed to update engineer", err.Error())
 	}
 
+	detachedEng, err := detachUploadedAvatar(r.Context(), userID, currentEng, engPayload.Avatar)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "authenticated_engineer.update.detach_avatar", "failed to update engineer", err.Error())
+	}
+
+	if detachedEng != nil {
+		updatedEng = detachedEng
+	}
+
+	queueModerationFlags(r.Context(), domain.ProfileTypeEngineer, updatedEng.ID, userID, moderation)
+
 	internal.LogInfo("Successfully updated authenticated engineer", map[string]interface{}{"engineer": updatedEng})
 	w.WriteResponse(http.StatusOK, map[string]*domain.Engineer{"engineer": updatedEng})
 	return nil
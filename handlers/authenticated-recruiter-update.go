@@ -27,11 +27,37 @@ func HandleAuthenticatedRecruiterUpdate(w internal.EnhancedResponseWriter, r *in
 		return internal.NewError(http.StatusBadRequest, "authenticated_recruiter.udpate.validate", "failed to update recruiter", err.Error())
 	}
 
+	moderation := domain.CurrentModerator().Moderate(recruiterPayload.ModerationContent())
+	err = moderation.Err()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "authenticated_recruiter.update.moderate", "failed to update recruiter", err.Error())
+	}
+
+	var currentRecruiter *domain.Recruiter
+	if recruiterPayload.Logo != "" {
+		currentRecruiter, err = dao.FindRecruiterByUser(r.Context(), userID)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "authenticated_recruiter.update.read_recruiter", "failed to update recruiter", err.Error())
+		}
+	}
+
 	updatedRecruiter, err := dao.UpdateRecruiterByUser(r.Context(), userID , &recruiterPayload)
 	if err != nil {
 		return internal.NewError(http.StatusInternalServerError, "authenticated_recruiter.update.update_table", "failed to update recruiter", err.Error())
 	}
 
+	// a logo set by URL replaces an uploaded one, whose thumbnails would otherwise linger
+	if currentRecruiter != nil && recruiterPayload.Logo != currentRecruiter.Logo && currentRecruiter.LogoImage != nil {
+		updatedRecruiter, err = dao.ReplaceRecruiterLogo(r.Context(), userID, recruiterPayload.Logo, nil)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "authenticated_recruiter.update.detach_logo", "failed to update recruiter", err.Error())
+		}
+
+		deleteImage(r.Context(), currentRecruiter.LogoImage, userID)
+	}
+
+	queueModerationFlags(r.Context(), domain.ProfileTypeRecruiter, updatedRecruiter.ID, userID, moderation)
+
 	internal.LogInfo("Successfully updated authenticated recruiter", map[string]interface{}{"recruiter": updatedRecruiter})
 	w.WriteResponse(http.StatusOK, map[string]*domain.Recruiter{"recruiter": updatedRecruiter})
 	return nil
@@ -14,6 +14,11 @@ func HandleAuthenticatedUserRead(w internal.EnhancedResponseWriter, r *internal.
 
 	internal.LogInfo("Starting read authenticated user", map[string]interface{}{"user_id": r.Context().Value("userID")})
 
+	unreadMessages, err := dao.CountUnreadMessages(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "authenticated_user.read.count_unread", "failed to read authenticated user", err.Error())
+	}
+
 	engineer, err := dao.FindEngineerByUser(r.Context(), userID)
 	if err != nil {
 		fmt.Println(err)
@@ -22,7 +27,7 @@ func HandleAuthenticatedUserRead(w internal.EnhancedResponseWriter, r *internal.
 
 	if engineer != nil {
 		internal.LogInfo("Successfully read authenticated user", map[string]interface{}{"user_id": r.Context().Value("userID"), "engineer_id": engineer.ID})
-		w.WriteResponse(http.StatusOK,  map[string]interface{}{"type": "engineer", "user": *engineer})
+		w.WriteResponse(http.StatusOK,  map[string]interface{}{"type": "engineer", "user": *engineer, "unread_messages": unreadMessages, "completeness": engineer.Completeness()})
 		return nil
 	}
 	
@@ -34,7 +39,7 @@ func HandleAuthenticatedUserRead(w internal.EnhancedResponseWriter, r *internal.
 
 	if recruiter != nil {
 		internal.LogInfo("Successfully read authenticated user", map[string]interface{}{"user_id": r.Context().Value("userID"), "recruiter_id": recruiter.ID})
-		w.WriteResponse(http.StatusOK,  map[string]interface{}{"type": "recruiter", "user": *recruiter})	
+		w.WriteResponse(http.StatusOK,  map[string]interface{}{"type": "recruiter", "user": *recruiter, "unread_messages": unreadMessages, "completeness": recruiter.Completeness()})	
 		return nil
 	}
 
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/gorilla/mux"
+)
+
+// HandleAvailabilityRead shows recruiters the engineer's windows and the slots already taken,
+// so that they can pick a free one.
+func HandleAvailabilityRead(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	engineerID := mux.Vars(r.Request)["engineerID"]
+
+	internal.LogInfo("Starting availability read", map[string]interface{}{"recruiter_id": recruiter.ID, "engineer_id": engineerID})
+
+	if !recruiter.IsMember {
+		return internal.NewError(http.StatusForbidden, "availability.read.check_membership", "failed to read availability", "interview booking is reserved to members")
+	}
+
+	engineer, err := dao.FindEngineerById(r.Context(), engineerID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "availability.read.read_engineer", "failed to read availability", err.Error())
+	}
+
+	if engineer == nil || !domain.NewViewer(r.Context()).CanSeeEngineer(engineer) {
+		return internal.NewError(http.StatusNotFound, "availability.read.read_engineer", "failed to read availability", "engineer not found")
+	}
+
+	availability, err := dao.FindAvailability(r.Context(), engineer.ID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "availability.read.read_availability", "failed to read availability", err.Error())
+	}
+
+	if availability == nil {
+		return internal.NewError(http.StatusNotFound, "availability.read.read_availability", "failed to read availability", "engineer has not published any availability")
+	}
+
+	now := time.Now()
+	busy, err := dao.ReadEngineerBusySlots(r.Context(), engineer.ID, now, now.Add(domain.MaxInterviewHorizon))
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "availability.read.read_busy_slots", "failed to read availability", err.Error())
+	}
+
+	internal.LogInfo("Successfully read availability", map[string]interface{}{"recruiter_id": recruiter.ID, "engineer_id": engineer.ID})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"availability": availability, "busy": busy})
+	return nil
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleAvailabilityUpdate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	var availabilityPayload domain.UpdateAvailabilityPayload
+
+	internal.LogInfo("Starting availability update", map[string]interface{}{"user_id": userID})
+
+	err := r.DecodeJSON(&w, &availabilityPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "availability.update.decode_body", "failed to update availability", err.Error())
+	}
+
+	err = availabilityPayload.Validate()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "availability.update.validate", "failed to update availability", err.Error())
+	}
+
+	engineer, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "availability.update.read_engineer", "failed to update availability", err.Error())
+	}
+
+	if engineer == nil {
+		return internal.NewError(http.StatusNotFound, "availability.update.read_engineer", "failed to update availability", "engineer not found")
+	}
+
+	availability := availabilityPayload.NewAvailability(engineer.ID)
+
+	err = dao.UpsertAvailability(r.Context(), availability)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "availability.update.update_table", "failed to update availability", err.Error())
+	}
+
+	internal.LogInfo("Successfully updated availability", map[string]interface{}{"user_id": userID, "engineer_id": engineer.ID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Availability{"availability": availability})
+	return nil
+}
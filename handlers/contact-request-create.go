@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+func HandleContactRequestCreate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	engineerID := mux.Vars(r.Request)["engineerID"]
+	contactRequestTemplateId := os.Getenv("CONTACT_REQUEST_TEMPLATE_ID")
+	var contactPayload domain.CreateContactRequestPayload
+
+	internal.LogInfo("Starting contact request creation", map[string]interface{}{"recruiter_id": recruiter.ID, "engineer_id": engineerID})
+
+	if !recruiter.IsMember {
+		return internal.NewError(http.StatusForbidden, "contact_request.create.check_membership", "failed to create contact request", "contact requests are reserved to members")
+	}
+
+	if recruiter.Hidden.Active(time.Now()) {
+		return internal.NewError(http.StatusForbidden, "contact_request.create.check_hidden", "failed to create contact request", "recruiter profile is hidden pending review")
+	}
+
+	err := r.DecodeJSON(&w, &contactPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "contact_request.create.decode_body", "failed to create contact request", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(contactPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "contact_request.create.validate_body", "failed to create contact request", err.Error())
+	}
+
+	engineer, err := dao.FindEngineerById(r.Context(), engineerID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "contact_request.create.read_engineer", "failed to create contact request", err.Error())
+	}
+
+	if engineer == nil || !domain.NewViewer(r.Context()).CanSeeEngineer(engineer) {
+		return internal.NewError(http.StatusNotFound, "contact_request.create.read_engineer", "failed to create contact request", "engineer not found")
+	}
+
+	now := time.Now()
+	existing, err := dao.FindOpenContactRequest(r.Context(), recruiter.ID, engineer.ID, now)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "contact_request.create.check_duplicate", "failed to create contact request", err.Error())
+	}
+
+	if existing != nil && existing.Status == domain.ContactStatusDeclined {
+		retryAt := existing.RespondedAt.Add(domain.ContactRequestCooldown)
+		return internal.NewError(http.StatusConflict, "contact_request.create.check_declined", "failed to create contact request", fmt.Sprintf("the engineer declined a contact request, a new one can be sent from %s", retryAt.Format(time.RFC3339)))
+	}
+
+	if existing != nil {
+		return internal.NewError(http.StatusConflict, "contact_request.create.check_duplicate", "failed to create contact request", fmt.Sprintf("a contact request is already %s", existing.Status))
+	}
+
+	contactRequest := contactPayload.NewContactRequest(recruiter, engineer)
+
+	err = dao.InsertNewContactRequest(r.Context(), contactRequest)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "contact_request.create.insert", "failed to create contact request", err.Error())
+	}
+
+	engineerUser, err := dao.FindUserById(r.Context(), engineer.UserID)
+	if err == nil && engineerUser != nil {
+		err = domain.SendTemplateEmail(contactRequestTemplateId, engineerUser.Email, map[string]interface{}{
+			"recruiter_name": recruiter.Firstname + " " + recruiter.Lastname,
+			"company": recruiter.Company,
+			"role_title": contactRequest.RoleTitle,
+			"role_description": contactRequest.RoleDescription,
+			"message": contactRequest.Message,
+			"url": fmt.Sprintf("%s/contact-requests/%s", domain.FrontendURL(), contactRequest.ID),
+		})
+	}
+	if err != nil {
+		internal.LogInfo("Failed to notify engineer of contact request", map[string]interface{}{"contact_request_id": contactRequest.ID, "error": err.Error()})
+	}
+
+	notifyUser(r.Context(), engineer.UserID, domain.NotificationContactRequest, map[string]interface{}{
+		"contact_request_id": contactRequest.ID.String(),
+		"company": recruiter.Company,
+		"role_title": contactRequest.RoleTitle,
+	})
+
+	internal.LogInfo("Successfully created contact request", map[string]interface{}{"recruiter_id": recruiter.ID, "engineer_id": engineer.ID, "contact_request_id": contactRequest.ID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.ContactRequest{"contactRequest": contactRequest})
+	return nil
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func HandleConversationCreate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	var conversationPayload domain.StartConversationPayload
+
+	internal.LogInfo("Starting conversation creation", map[string]interface{}{"recruiter_id": recruiter.ID})
+
+	if recruiter.Hidden.Active(time.Now()) {
+		return internal.NewError(http.StatusForbidden, "conversation.create.check_hidden", "failed to create conversation", "recruiter profile is hidden pending review")
+	}
+
+	err := r.DecodeJSON(&w, &conversationPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "conversation.create.decode_body", "failed to create conversation", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(conversationPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "conversation.create.validate_body", "failed to create conversation", err.Error())
+	}
+
+	engineer, err := dao.FindEngineerById(r.Context(), conversationPayload.EngineerID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "conversation.create.read_engineer", "failed to create conversation", err.Error())
+	}
+
+	viewer := domain.NewViewer(r.Context())
+	if engineer == nil || !viewer.CanSeeEngineer(engineer) {
+		return internal.NewError(http.StatusNotFound, "conversation.create.read_engineer", "failed to create conversation", "engineer not found")
+	}
+
+	if !viewer.Contacts[engineer.ID] {
+		return internal.NewError(http.StatusForbidden, "conversation.create.check_contact", "failed to create conversation", "the engineer has not accepted a contact request")
+	}
+
+	blocked, err := dao.IsBlocked(r.Context(), recruiter.UserID, engineer.UserID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "conversation.create.check_block", "failed to create conversation", err.Error())
+	}
+
+	if blocked {
+		return internal.NewError(http.StatusForbidden, "conversation.create.check_block", "failed to create conversation", "messaging between these users is blocked")
+	}
+
+	conversation, err := domain.NewConversation(recruiter, engineer)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "conversation.create.new_conversation", "failed to create conversation", err.Error())
+	}
+
+	conversation, err = dao.EnsureConversation(r.Context(), conversation)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "conversation.create.insert", "failed to create conversation", err.Error())
+	}
+
+	message := conversation.NewMessage(recruiter.UserID, conversationPayload.Body)
+
+	err = dao.InsertMessage(r.Context(), message)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "conversation.create.insert_message", "failed to create conversation", err.Error())
+	}
+
+	notifyUser(r.Context(), message.RecipientID, domain.NotificationMessage, map[string]interface{}{
+		"conversation_id": conversation.ID.String(),
+		"message_id": message.ID.String(),
+		"preview": message.Preview(),
+	})
+
+	internal.LogInfo("Successfully created conversation", map[string]interface{}{"recruiter_id": recruiter.ID, "engineer_id": engineer.ID, "conversation_id": conversation.ID})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"conversation": conversation, "message": message})
+	return nil
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleConversationList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting conversation list", map[string]interface{}{"user_id": userID})
+
+	pagination, err := domain.NewPagination(r.URL.Query(), 20, 100)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "conversation.list.parse_pagination", "failed to list conversations", err.Error())
+	}
+
+	conversations, err := dao.ReadConversationsByUser(r.Context(), userID, pagination)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "conversation.list.read_conversations", "failed to list conversations", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed conversations", map[string]interface{}{"user_id": userID})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"conversations": conversations, "pagination": pagination})
+	return nil
+}
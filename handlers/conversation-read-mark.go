@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleConversationReadMark(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	conversationID := mux.Vars(r.Request)["conversationID"]
+
+	internal.LogInfo("Starting conversation read marking", map[string]interface{}{"user_id": userID, "conversation_id": conversationID})
+
+	conversation, err := dao.FindConversationForUser(r.Context(), conversationID, userID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "conversation.read_mark.read_conversation", "failed to mark conversation as read", err.Error())
+	}
+
+	if conversation == nil {
+		return internal.NewError(http.StatusNotFound, "conversation.read_mark.read_conversation", "failed to mark conversation as read", "conversation not found")
+	}
+
+	marked, err := dao.MarkConversationRead(r.Context(), conversation.ID, userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "conversation.read_mark.update", "failed to mark conversation as read", err.Error())
+	}
+
+	internal.LogInfo("Successfully marked conversation as read", map[string]interface{}{"user_id": userID, "conversation_id": conversation.ID, "message_count": marked})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"conversationId": conversation.ID, "markedRead": marked})
+	return nil
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleEngineerAvatarRemove(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting engineer avatar removal", map[string]interface{}{"user_id": userID})
+
+	currentEng, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.avatar.remove.read_engineer", "failed to remove avatar", err.Error())
+	}
+
+	if currentEng == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.avatar.remove.read_engineer", "failed to remove avatar", "engineer not found")
+	}
+
+	updatedEng, err := dao.ReplaceEngineerAvatar(r.Context(), userID, "", nil)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.avatar.remove.update_table", "failed to remove avatar", err.Error())
+	}
+
+	if updatedEng == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.avatar.remove.update_table", "failed to remove avatar", "engineer not found")
+	}
+
+	if currentEng.AvatarImage != nil {
+		deleteImage(r.Context(), currentEng.AvatarImage, userID)
+	}
+
+	internal.LogInfo("Successfully removed engineer avatar", map[string]interface{}{"user_id": userID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Engineer{"engineer": updatedEng})
+	return nil
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleEngineerAvatarUpload(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting engineer avatar upload", map[string]interface{}{"user_id": userID})
+
+	currentEng, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.avatar.upload.read_engineer", "failed to upload avatar", err.Error())
+	}
+
+	if currentEng == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.avatar.upload.read_engineer", "failed to upload avatar", "engineer not found")
+	}
+
+	thumbnails, customErr := readImageUpload(w, r, domain.AvatarImageSpec, "engineer.avatar.upload", "failed to upload avatar")
+	if customErr != nil {
+		return customErr
+	}
+
+	avatarURL, uploaded, err := domain.AvatarImageSpec.Store(r.Context(), domain.CurrentBlobStorage(), currentEng.ID, thumbnails)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.avatar.upload.store_image", "failed to upload avatar", err.Error())
+	}
+
+	updatedEng, err := dao.ReplaceEngineerAvatar(r.Context(), userID, avatarURL, uploaded)
+	if err != nil || updatedEng == nil {
+		deleteImage(r.Context(), uploaded, userID)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "engineer.avatar.upload.update_table", "failed to upload avatar", err.Error())
+		}
+		return internal.NewError(http.StatusNotFound, "engineer.avatar.upload.update_table", "failed to upload avatar", "engineer not found")
+	}
+
+	if currentEng.AvatarImage != nil {
+		deleteImage(r.Context(), currentEng.AvatarImage, userID)
+	}
+
+	internal.LogInfo("Successfully uploaded engineer avatar", map[string]interface{}{"user_id": userID, "avatar": avatarURL})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Engineer{"engineer": updatedEng})
+	return nil
+}
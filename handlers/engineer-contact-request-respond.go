@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleEngineerContactRequestRespond(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	requestID := mux.Vars(r.Request)["requestID"]
+	contactResponseTemplateId := os.Getenv("CONTACT_RESPONSE_TEMPLATE_ID")
+	var responsePayload domain.RespondContactRequestPayload
+
+	internal.LogInfo("Starting contact request response", map[string]interface{}{"user_id": userID, "contact_request_id": requestID})
+
+	err := r.DecodeJSON(&w, &responsePayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.contact_request.respond.decode_body", "failed to respond to contact request", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(responsePayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.contact_request.respond.validate_body", "failed to respond to contact request", err.Error())
+	}
+
+	engineer, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.contact_request.respond.read_engineer", "failed to respond to contact request", err.Error())
+	}
+
+	if engineer == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.contact_request.respond.read_engineer", "failed to respond to contact request", "engineer not found")
+	}
+
+	user, err := dao.FindUserById(r.Context(), userID)
+	if err != nil || user == nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.contact_request.respond.read_user", "failed to respond to contact request", "failed to read engineer user")
+	}
+
+	contactRequest, err := dao.RespondContactRequest(r.Context(), engineer.ID, requestID, responsePayload.Status, user.Email)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.contact_request.respond.update_table", "failed to respond to contact request", err.Error())
+	}
+
+	if contactRequest == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.contact_request.respond.update_table", "failed to respond to contact request", "pending contact request not found")
+	}
+
+	recruiterUser, err := dao.FindUserById(r.Context(), contactRequest.RecruiterUserID)
+	if err == nil && recruiterUser != nil {
+		err = domain.SendTemplateEmail(contactResponseTemplateId, recruiterUser.Email, map[string]interface{}{
+			"status": contactRequest.Status,
+			"role_title": contactRequest.RoleTitle,
+			"engineer_name": engineer.Firstname + " " + engineer.Lastname,
+			"url": fmt.Sprintf("%s/engineers/%s", domain.FrontendURL(), engineer.ID),
+		})
+	}
+	if err != nil {
+		internal.LogInfo("Failed to notify recruiter of contact request response", map[string]interface{}{"contact_request_id": contactRequest.ID, "error": err.Error()})
+	}
+
+	internal.LogInfo("Successfully responded to contact request", map[string]interface{}{"user_id": userID, "contact_request_id": requestID, "status": contactRequest.Status})
+	w.WriteResponse(http.StatusOK, map[string]*domain.ContactRequest{"contactRequest": contactRequest})
+	return nil
+}
@@ -25,11 +25,27 @@ func HandleEngineerCreate(w internal.EnhancedResponseWriter, r *internal.Enhance
 		return internal.NewError(http.StatusBadRequest, "engineer.create.validate_body", "failed to create new engineer", err.Error())
 	}
 
+	err = domain.EngineerFieldPolicy.Validate(engPayload.FieldVisibility)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.create.validate_field_visibility", "failed to create new engineer", err.Error())
+	}
+
+	moderation := domain.CurrentModerator().Moderate(engPayload.ModerationContent())
+	err = moderation.Err()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.create.moderate", "failed to create new engineer", err.Error())
+	}
+
 	eng, err := engPayload.NewEngineer(r.Context())
 	if err != nil {
 		return internal.NewError(http.StatusInternalServerError, "engineer.create.create_new_engineer", "failed to create new engineer", err.Error())
 	}
 
+	err = eng.Geocode(r.Context())
+	if err != nil {
+		internal.LogInfo("Failed to geocode engineer location", map[string]interface{}{"engineerId": eng.ID, "error": err.Error()})
+	}
+
 	err = internal.Validate(r.Context(), eng.ID)
 	if err != nil {
 		return internal.NewError(http.StatusBadRequest, "engineer.create.validate_new_engineer", "failed to create new engineer", err.Error())
@@ -40,6 +56,8 @@ func HandleEngineerCreate(w internal.EnhancedResponseWriter, r *internal.Enhance
 		return internal.NewError(http.StatusInternalServerError, "engineer.create.insert", "failed to create new engineer", err.Error())
 	}
 
+	queueModerationFlags(r.Context(), domain.ProfileTypeEngineer, eng.ID, eng.UserID, moderation)
+
 	internal.LogInfo("Successfully created new engineer", map[string]interface{}{"engineerId": eng.ID})
 	w.WriteResponse(http.StatusOK, map[string]uuid.UUID{"engineerId": eng.ID})
 	return nil
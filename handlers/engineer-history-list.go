@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleEngineerHistoryList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting engineer history list", map[string]interface{}{"user_id": userID})
+
+	pagination, err := domain.NewPagination(r.URL.Query(), 20, 100)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.history.list.parse_pagination", "failed to list profile history", err.Error())
+	}
+
+	engineer, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.history.list.read_engineer", "failed to list profile history", err.Error())
+	}
+
+	if engineer == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.history.list.read_engineer", "failed to list profile history", "engineer not found")
+	}
+
+	revisions, err := dao.ReadProfileHistory(r.Context(), domain.ProfileTypeEngineer, engineer.ID, pagination)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.history.list.read_history", "failed to list profile history", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed engineer history", map[string]interface{}{"user_id": userID})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"version": engineer.Version, "history": revisions, "pagination": pagination})
+	return nil
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleEngineerHistoryRestore(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting engineer history restore", map[string]interface{}{"user_id": userID, "version": mux.Vars(r.Request)["version"]})
+
+	version, err := strconv.Atoi(mux.Vars(r.Request)["version"])
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.history.restore.parse_version", "failed to restore profile version", err.Error())
+	}
+
+	restoredEng, err := dao.RestoreEngineerVersion(r.Context(), userID, version)
+	if errors.Is(err, domain.ErrUnknownProfileVersion) {
+		return internal.NewError(http.StatusNotFound, "engineer.history.restore.read_version", "failed to restore profile version", err.Error())
+	}
+	if errors.Is(err, domain.ErrProfileVersionChanged) {
+		return internal.NewError(http.StatusConflict, "engineer.history.restore.update_table", "failed to restore profile version", err.Error())
+	}
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.history.restore.update_table", "failed to restore profile version", err.Error())
+	}
+
+	if restoredEng == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.history.restore.read_engineer", "failed to restore profile version", "engineer not found")
+	}
+
+	internal.LogInfo("Successfully restored engineer version", map[string]interface{}{"user_id": userID, "version": version})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Engineer{"engineer": restoredEng})
+	return nil
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleEngineerInterviewRequestList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting engineer interview request list", map[string]interface{}{"user_id": userID})
+
+	engineer, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.interview_request.list.read_engineer", "failed to list interview requests", err.Error())
+	}
+
+	if engineer == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.interview_request.list.read_engineer", "failed to list interview requests", "engineer not found")
+	}
+
+	interviews, err := dao.ReadInterviewRequestsByEngineer(r.Context(), engineer.ID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.interview_request.list.read_requests", "failed to list interview requests", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed engineer interview requests", map[string]interface{}{"user_id": userID, "engineer_id": engineer.ID})
+	w.WriteResponse(http.StatusOK, map[string][]*domain.InterviewRequest{"interviewRequests": interviews})
+	return nil
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleEngineerInterviewRequestRespond(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	requestID := mux.Vars(r.Request)["requestID"]
+	var responsePayload domain.RespondInterviewRequestPayload
+
+	internal.LogInfo("Starting interview request response", map[string]interface{}{"user_id": userID, "interview_request_id": requestID})
+
+	err := r.DecodeJSON(&w, &responsePayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.interview_request.respond.decode_body", "failed to respond to interview request", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(responsePayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.interview_request.respond.validate_body", "failed to respond to interview request", err.Error())
+	}
+
+	engineer, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.interview_request.respond.read_engineer", "failed to respond to interview request", err.Error())
+	}
+
+	if engineer == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.interview_request.respond.read_engineer", "failed to respond to interview request", "engineer not found")
+	}
+
+	interview, err := dao.FindPendingInterviewRequest(r.Context(), engineer.ID, requestID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.interview_request.respond.read_request", "failed to respond to interview request", err.Error())
+	}
+
+	if interview == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.interview_request.respond.read_request", "failed to respond to interview request", "pending interview request not found")
+	}
+
+	if responsePayload.Status == domain.InterviewStatusConfirmed {
+		unlock, err := dao.LockInterviewParticipants(r.Context(), interview.EngineerID, interview.RecruiterID)
+		if errors.Is(err, domain.ErrSlotBusy) {
+			return internal.NewError(http.StatusConflict, "engineer.interview_request.respond.lock_slot", "failed to respond to interview request", err.Error())
+		}
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "engineer.interview_request.respond.lock_slot", "failed to respond to interview request", err.Error())
+		}
+		defer unlock()
+
+		conflict, err := dao.HasInterviewConflict(r.Context(), interview.EngineerID, interview.RecruiterID, interview.Start, interview.End, []string{domain.InterviewStatusConfirmed}, interview.ID)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "engineer.interview_request.respond.check_conflicts", "failed to respond to interview request", err.Error())
+		}
+
+		if conflict {
+			return internal.NewError(http.StatusConflict, "engineer.interview_request.respond.check_conflicts", "failed to respond to interview request", "the slot overlaps a confirmed interview")
+		}
+	}
+
+	interview, err = dao.RespondInterviewRequest(r.Context(), interview.ID, responsePayload.Status)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.interview_request.respond.update_table", "failed to respond to interview request", err.Error())
+	}
+
+	if interview == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.interview_request.respond.update_table", "failed to respond to interview request", "pending interview request not found")
+	}
+
+	if interview.Status == domain.InterviewStatusConfirmed {
+		err = sendInterviewInvites(r, interview)
+		if err != nil {
+			internal.LogInfo("Failed to send interview invites", map[string]interface{}{"interview_request_id": interview.ID, "error": err.Error()})
+		}
+	}
+
+	internal.LogInfo("Successfully responded to interview request", map[string]interface{}{"user_id": userID, "interview_request_id": interview.ID, "status": interview.Status})
+	w.WriteResponse(http.StatusOK, map[string]*domain.InterviewRequest{"interviewRequest": interview})
+	return nil
+}
+
+// sendInterviewInvites emails the same calendar invite to the recruiter and the engineer.
+func sendInterviewInvites(r *internal.EnhancedRequest, interview *domain.InterviewRequest) error {
+	templateId := os.Getenv("INTERVIEW_CONFIRMED_TEMPLATE_ID")
+
+	recruiterUser, err := dao.FindUserById(r.Context(), interview.RecruiterUserID)
+	if err != nil || recruiterUser == nil {
+		return err
+	}
+
+	engineerUser, err := dao.FindUserById(r.Context(), interview.EngineerUserID)
+	if err != nil || engineerUser == nil {
+		return err
+	}
+
+	invite := domain.EmailAttachment{
+		Filename: "interview.ics",
+		ContentType: "text/calendar; method=REQUEST",
+		Content: interview.ICS(recruiterUser.Email, engineerUser.Email, time.Now()),
+	}
+
+	variables := map[string]interface{}{
+		"recruiter_name": interview.RecruiterName,
+		"engineer_name": interview.EngineerName,
+		"company": interview.Company,
+		"start": interview.Start.Format(time.RFC3339),
+		"end": interview.End.Format(time.RFC3339),
+	}
+
+	for _, email := range []string{recruiterUser.Email, engineerUser.Email} {
+		err = domain.SendTemplateEmailWithAttachments(templateId, email, variables, []domain.EmailAttachment{invite})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,16 +2,19 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 	"angular-talents-backend/dao"
 	"angular-talents-backend/domain"
 	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
 )
 
 func HandleEngineerList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError{
 	internal.LogInfo("Starting engineer list", map[string]interface{}{"user_id": r.Context().Value("userID")})
 	isMember := r.Context().Value("isMember").(bool)
 
-	listParams, err := domain.NewListEngineerParams(isMember, r.URL.Query())
+	listParams, err := domain.NewListEngineerParams(r.Context(), isMember, r.URL.Query())
 	if err != nil {
 		return internal.NewError(http.StatusBadRequest, "engineer.list.new_query_params", "failed to list engineers", err.Error())
 	}
@@ -21,7 +24,26 @@ func HandleEngineerList(w internal.EnhancedResponseWriter, r *internal.EnhancedR
 		return internal.NewError(http.StatusInternalServerError, "engineer.list.read_engineers", "failed to list engineers", err.Error())
 	}
 
+	engineerIDs := make([]uuid.UUID, 0, len(engineers))
+	views := make([]map[string]interface{}, 0, len(engineers))
+	for _, engineer := range engineers {
+		if !listParams.Viewer.Owns(engineer) {
+			engineerIDs = append(engineerIDs, engineer.ID)
+		}
+
+		view, err := listParams.Viewer.EngineerView(engineer)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "engineer.list.apply_policy", "failed to list engineers", err.Error())
+		}
+		views = append(views, view)
+	}
+
+	err = dao.RecordSearchAppearances(r.Context(), engineerIDs, domain.StatsDay(time.Now()))
+	if err != nil {
+		internal.LogInfo("Failed to record search appearances", map[string]interface{}{"error": err.Error()})
+	}
+
 	internal.LogInfo("Successfully listed engineers", map[string]interface{}{"user_id": r.Context().Value("userID")})
-	w.WriteResponse(http.StatusOK,  map[string][]*domain.Engineer{"engineers": engineers})
+	w.WriteResponse(http.StatusOK,  map[string]interface{}{"engineers": views, "upgrade_required": listParams.Viewer.UpgradeRequired()})
 	return nil
 }
\ No newline at end of file
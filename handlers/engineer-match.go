@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+)
+
+func HandleEngineerMatch(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	viewer := domain.NewViewer(r.Context())
+	var matchSpec domain.MatchSpec
+
+	internal.LogInfo("Starting engineer match", map[string]interface{}{"recruiter_id": recruiter.ID})
+
+	if viewer.UpgradeRequired() {
+		return internal.NewError(http.StatusForbidden, "engineer.match.check_membership", "failed to match engineers", "matching is reserved to members")
+	}
+
+	err := r.DecodeJSON(&w, &matchSpec)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.match.decode_body", "failed to match engineers", err.Error())
+	}
+
+	err = matchSpec.Validate()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.match.validate_body", "failed to match engineers", err.Error())
+	}
+
+	matcher, err := domain.NewMatcher(r.Context(), &matchSpec, viewer)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.match.resolve_locations", "failed to match engineers", err.Error())
+	}
+
+	candidates, total, err := dao.ReadMatchCandidates(r.Context(), viewer, matcher)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.match.read_engineers", "failed to match engineers", err.Error())
+	}
+
+	ranked := matcher.Rank(candidates)
+	results := make([]*domain.MatchResult, 0, len(ranked))
+	for _, match := range ranked {
+		view, err := viewer.EngineerView(match.Engineer)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "engineer.match.apply_policy", "failed to match engineers", err.Error())
+		}
+		results = append(results, &domain.MatchResult{Engineer: view, Score: match.Score, Factors: match.Factors})
+	}
+
+	// only the best prescored candidates are scored when too many pass the must-haves
+	internal.LogInfo("Successfully matched engineers", map[string]interface{}{"recruiter_id": recruiter.ID, "candidates": len(candidates), "total": total, "results": len(results)})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{
+		"matches": results,
+		"candidates": len(candidates),
+		"totalCandidates": total,
+		"truncated": total > int64(len(candidates)),
+	})
+	return nil
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"time"
 	"angular-talents-backend/dao"
 	"angular-talents-backend/domain"
 	"angular-talents-backend/internal"
@@ -13,7 +14,7 @@ import (
 func HandleEngineerRead(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
 	params := mux.Vars(r.Request)
 	engineerID := params["engineerID"]
-	isMember := r.Context().Value("isMember").(bool)
+	viewer := domain.NewViewer(r.Context())
 
 	internal.LogInfo("Starting engineer read", map[string]interface{}{"user_id": r.Context().Value("userID"), "engineer_id": engineerID })
 
@@ -28,18 +29,32 @@ func HandleEngineerRead(w internal.EnhancedResponseWriter, r *internal.EnhancedR
 		return internal.NewError(http.StatusInternalServerError, "engineer.read.read_by_id", "failed to read engineer", err.Error())
 	}
 	
-	if engineer == nil {
+	if engineer == nil || !viewer.CanSeeEngineer(engineer) {
 		return internal.NewError(http.StatusNotFound, "engineer.read.read_by_id", "failed to read engineer", "engineer not found")
 	}
 
-	if !isMember {
-		partialEngineer := engineer.NewPartialEngineer()
-		internal.LogInfo("Successfully read engineer partially concealed", map[string]interface{}{"user_id": r.Context().Value("userID"), "engineer_id": engineerID })
-		w.WriteResponse(http.StatusOK,  map[string]domain.PartialEngineer{"engineer": *partialEngineer})	
-		return nil
+	view, err := viewer.EngineerView(engineer)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.read.apply_policy", "failed to read engineer", err.Error())
+	}
+
+	profileView := domain.NewProfileView(r.Context(), engineer, r.ClientIP(), r.UserAgent(), time.Now())
+	if profileView != nil {
+		isNewView, err := dao.RecordProfileView(r.Context(), profileView)
+		if err != nil {
+			internal.LogInfo("Failed to record profile view", map[string]interface{}{"engineer_id": engineer.ID, "error": err.Error()})
+		}
+
+		if isNewView && viewer.IsAuthenticated() {
+			notifyUser(r.Context(), engineer.UserID, domain.NotificationProfileView, map[string]interface{}{
+				"engineer_id": engineer.ID.String(),
+				"is_member": viewer.IsMember,
+				"company": profileView.Company,
+			})
+		}
 	}
 
-	internal.LogInfo("Successfully read engineer", map[string]interface{}{"user_id": r.Context().Value("userID"), "engineer_id": engineerID })
-	w.WriteResponse(http.StatusOK,  map[string]domain.Engineer{"engineer": *engineer})	
+	internal.LogInfo("Successfully read engineer", map[string]interface{}{"user_id": r.Context().Value("userID"), "engineer_id": engineerID, "audience": viewer.Audience(engineer) })
+	w.WriteResponse(http.StatusOK,  map[string]interface{}{"engineer": view, "upgrade_required": viewer.UpgradeRequired()})	
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/gorilla/mux"
+)
+
+var resumeFilenameUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+func HandleEngineerResumeRead(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	engineerID := mux.Vars(r.Request)["engineerID"]
+	viewer := domain.NewViewer(r.Context())
+
+	internal.LogInfo("Starting engineer resume read", map[string]interface{}{"user_id": r.Context().Value("userID"), "engineer_id": engineerID})
+
+	if len(engineerID) != 36 {
+		return internal.NewError(http.StatusBadRequest, "engineer.resume.validate_params", "failed to render resume", "invalid engineerId param")
+	}
+
+	engineer, err := dao.FindEngineerById(r.Context(), engineerID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.resume.read_by_id", "failed to render resume", err.Error())
+	}
+
+	if engineer == nil || !viewer.CanSeeEngineer(engineer) {
+		return internal.NewError(http.StatusNotFound, "engineer.resume.read_by_id", "failed to render resume", "engineer not found")
+	}
+
+	concealed, err := viewer.ConcealedEngineer(engineer)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.resume.apply_policy", "failed to render resume", err.Error())
+	}
+
+	note := ""
+	if viewer.UpgradeRequired() {
+		note = "Contact details and the full profile are visible to Angular Talents members."
+	}
+
+	pdf := domain.RenderResume(concealed, note, time.Now())
+
+	filename := strings.Trim(resumeFilenameUnsafe.ReplaceAllString(strings.ToLower(concealed.Firstname+"-"+concealed.Lastname), "-"), "-")
+	if filename == "" {
+		filename = "engineer-" + engineer.ID.String()[:8]
+	}
+
+	internal.LogInfo("Successfully rendered engineer resume", map[string]interface{}{"user_id": r.Context().Value("userID"), "engineer_id": engineerID, "audience": viewer.Audience(engineer)})
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s-resume.pdf"`, filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(pdf)))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdf)
+	return nil
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleEngineerSectionEntryAdd(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	sectionName := mux.Vars(r.Request)["section"]
+
+	internal.LogInfo("Starting engineer section entry addition", map[string]interface{}{"user_id": userID, "section": sectionName})
+
+	section, ok := domain.ProfileSections[sectionName]
+	if !ok {
+		return internal.NewError(http.StatusNotFound, "engineer.section.add.read_section", "failed to add entry", "unknown profile section")
+	}
+
+	entryPayload := section.NewPayload()
+	err := r.DecodeJSON(&w, entryPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.section.add.decode_body", "failed to add entry", err.Error())
+	}
+
+	err = entryPayload.Validate(time.Now())
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.section.add.validate_body", "failed to add entry", err.Error())
+	}
+
+	moderation := domain.CurrentModerator().Moderate(entryPayload.ModerationContent())
+	err = moderation.Err()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.section.add.moderate", "failed to add entry", err.Error())
+	}
+
+	entryID := uuid.New()
+	updatedEng, err := dao.AddEngineerSectionEntry(r.Context(), userID, section, entryPayload.Entry(entryID))
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.section.add.update_table", "failed to add entry", err.Error())
+	}
+
+	if updatedEng == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.section.add.update_table", "failed to add entry", "engineer not found")
+	}
+
+	queueModerationFlags(r.Context(), domain.ProfileTypeEngineer, updatedEng.ID, userID, moderation)
+
+	internal.LogInfo("Successfully added engineer section entry", map[string]interface{}{"user_id": userID, "section": sectionName, "entry_id": entryID})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"engineer": updatedEng, "entryId": entryID})
+	return nil
+}
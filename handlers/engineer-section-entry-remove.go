@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleEngineerSectionEntryRemove(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	params := mux.Vars(r.Request)
+	sectionName := params["section"]
+
+	internal.LogInfo("Starting engineer section entry removal", map[string]interface{}{"user_id": userID, "section": sectionName, "entry_id": params["entryID"]})
+
+	section, ok := domain.ProfileSections[sectionName]
+	if !ok {
+		return internal.NewError(http.StatusNotFound, "engineer.section.remove.read_section", "failed to remove entry", "unknown profile section")
+	}
+
+	entryID, err := uuid.Parse(params["entryID"])
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.section.remove.parse_id", "failed to remove entry", err.Error())
+	}
+
+	updatedEng, err := dao.RemoveEngineerSectionEntry(r.Context(), userID, section, entryID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.section.remove.update_table", "failed to remove entry", err.Error())
+	}
+
+	if updatedEng == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.section.remove.update_table", "failed to remove entry", "entry not found")
+	}
+
+	internal.LogInfo("Successfully removed engineer section entry", map[string]interface{}{"user_id": userID, "section": sectionName, "entry_id": entryID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Engineer{"engineer": updatedEng})
+	return nil
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleEngineerSectionEntryUpdate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	params := mux.Vars(r.Request)
+	sectionName := params["section"]
+
+	internal.LogInfo("Starting engineer section entry update", map[string]interface{}{"user_id": userID, "section": sectionName, "entry_id": params["entryID"]})
+
+	section, ok := domain.ProfileSections[sectionName]
+	if !ok {
+		return internal.NewError(http.StatusNotFound, "engineer.section.update.read_section", "failed to update entry", "unknown profile section")
+	}
+
+	entryID, err := uuid.Parse(params["entryID"])
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.section.update.parse_id", "failed to update entry", err.Error())
+	}
+
+	entryPayload := section.NewPayload()
+	err = r.DecodeJSON(&w, entryPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.section.update.decode_body", "failed to update entry", err.Error())
+	}
+
+	err = entryPayload.Validate(time.Now())
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.section.update.validate_body", "failed to update entry", err.Error())
+	}
+
+	moderation := domain.CurrentModerator().Moderate(entryPayload.ModerationContent())
+	err = moderation.Err()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.section.update.moderate", "failed to update entry", err.Error())
+	}
+
+	updatedEng, err := dao.ReplaceEngineerSectionEntry(r.Context(), userID, section, entryID, entryPayload.Entry(entryID))
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.section.update.update_table", "failed to update entry", err.Error())
+	}
+
+	if updatedEng == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.section.update.update_table", "failed to update entry", "entry not found")
+	}
+
+	queueModerationFlags(r.Context(), domain.ProfileTypeEngineer, updatedEng.ID, userID, moderation)
+
+	internal.LogInfo("Successfully updated engineer section entry", map[string]interface{}{"user_id": userID, "section": sectionName, "entry_id": entryID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Engineer{"engineer": updatedEng})
+	return nil
+}
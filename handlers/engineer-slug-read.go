@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"net/url"
+
+	"github.com/gorilla/mux"
+)
+
+// HandleEngineerSlugRead serves the public view of the engineer holding the slug. Previous slugs
+// redirect permanently to the current one, so shared links keep working.
+func HandleEngineerSlugRead(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	slug := domain.NormalizeSlug(mux.Vars(r.Request)["slug"])
+	viewer := domain.NewViewer(r.Context())
+
+	internal.LogInfo("Starting engineer slug read", map[string]interface{}{"slug": slug})
+
+	engineerSlug, err := dao.FindEngineerSlug(r.Context(), slug)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.slug.read.read_slug", "failed to read engineer", err.Error())
+	}
+
+	if engineerSlug == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.slug.read.read_slug", "failed to read engineer", "engineer not found")
+	}
+
+	engineer, err := dao.FindEngineerById(r.Context(), engineerSlug.EngineerID.String())
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.slug.read.read_by_id", "failed to read engineer", err.Error())
+	}
+
+	if engineer == nil || !viewer.CanSeeEngineer(engineer) {
+		return internal.NewError(http.StatusNotFound, "engineer.slug.read.read_by_id", "failed to read engineer", "engineer not found")
+	}
+
+	if engineer.Slug != slug && engineer.Slug != "" {
+		internal.LogInfo("Redirecting previous engineer slug", map[string]interface{}{"slug": slug, "current_slug": engineer.Slug})
+		http.Redirect(w, r.Request, "/e/"+url.PathEscape(engineer.Slug), http.StatusMovedPermanently)
+		return nil
+	}
+
+	concealed, err := viewer.ConcealedEngineer(engineer)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.slug.read.apply_policy", "failed to read engineer", err.Error())
+	}
+
+	internal.LogInfo("Successfully read engineer by slug", map[string]interface{}{"slug": slug, "engineer_id": engineer.ID})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"engineer": concealed.NewPartialEngineer(), "url": domain.EngineerPublicURL(engineer)})
+	return nil
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+func HandleEngineerSlugUpdate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	var slugPayload domain.ClaimSlugPayload
+
+	internal.LogInfo("Starting engineer slug update", map[string]interface{}{"user_id": userID})
+
+	err := r.DecodeJSON(&w, &slugPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.slug.update.decode_body", "failed to update slug", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(slugPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.slug.update.validate", "failed to update slug", err.Error())
+	}
+
+	slug := domain.NormalizeSlug(slugPayload.Slug)
+	err = domain.ValidateSlug(slug)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.slug.update.validate_slug", "failed to update slug", err.Error())
+	}
+
+	engineer, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.slug.update.read_engineer", "failed to update slug", err.Error())
+	}
+
+	if engineer == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.slug.update.read_engineer", "failed to update slug", "engineer not found")
+	}
+
+	updatedEng, err := dao.ClaimEngineerSlug(r.Context(), engineer, slug)
+	if errors.Is(err, domain.ErrSlugTaken) {
+		return internal.NewError(http.StatusConflict, "engineer.slug.update.claim_slug", "failed to update slug", err.Error())
+	}
+	if errors.Is(err, domain.ErrTooManySlugs) {
+		return internal.NewError(http.StatusBadRequest, "engineer.slug.update.claim_slug", "failed to update slug", err.Error())
+	}
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.slug.update.claim_slug", "failed to update slug", err.Error())
+	}
+
+	if updatedEng == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.slug.update.claim_slug", "failed to update slug", "engineer not found")
+	}
+
+	internal.LogInfo("Successfully updated engineer slug", map[string]interface{}{"user_id": userID, "slug": slug})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Engineer{"engineer": updatedEng})
+	return nil
+}
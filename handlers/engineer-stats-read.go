@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleEngineerStatsRead(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	days := domain.DefaultStatsDays
+
+	internal.LogInfo("Starting engineer stats read", map[string]interface{}{"user_id": userID})
+
+	if r.URL.Query().Get("days") != "" {
+		parsedDays, err := strconv.Atoi(r.URL.Query().Get("days"))
+		if err != nil || parsedDays < 1 || parsedDays > domain.MaxStatsDays {
+			return internal.NewError(http.StatusBadRequest, "engineer.stats.parse_days", "failed to read engineer stats", fmt.Sprintf("days must be between 1 and %d", domain.MaxStatsDays))
+		}
+		days = parsedDays
+	}
+
+	engineer, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.stats.read_engineer", "failed to read engineer stats", err.Error())
+	}
+
+	if engineer == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.stats.read_engineer", "failed to read engineer stats", "engineer not found")
+	}
+
+	to := time.Now().UTC()
+	from := to.AddDate(0, 0, -(days - 1))
+
+	stats, err := dao.ReadEngineerStats(r.Context(), engineer.ID, domain.StatsDay(from), domain.StatsDay(to))
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.stats.read_stats", "failed to read engineer stats", err.Error())
+	}
+
+	stats.DailyViews = domain.FillDailyCounts(stats.DailyViews, from, to)
+	stats.DailySearchAppearances = domain.FillDailyCounts(stats.DailySearchAppearances, from, to)
+
+	internal.LogInfo("Successfully read engineer stats", map[string]interface{}{"user_id": userID, "engineer_id": engineer.ID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.EngineerStats{"stats": stats})
+	return nil
+}
@@ -27,11 +27,36 @@ func HandleEngineerUpdate(w internal.EnhancedResponseWriter, r *internal.Enhance
 		return internal.NewError(http.StatusBadRequest, "engineer.udpate.validate", "failed to update engineer", err.Error())
 	}
 
+	moderation := domain.CurrentModerator().Moderate(engPayload.ModerationContent())
+	err = moderation.Err()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "engineer.update.moderate", "failed to update engineer", err.Error())
+	}
+
+	currentEng, err := dao.FindEngineerById(r.Context(), engineerID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "engineer.update.read_engineer", "failed to update engineer", err.Error())
+	}
+
+	if currentEng == nil {
+		return internal.NewError(http.StatusNotFound, "engineer.update.read_engineer", "failed to update engineer", "engineer not found")
+	}
+
+	err = engPayload.Geocode(r.Context(), currentEng)
+	if err != nil {
+		internal.LogInfo("Failed to geocode engineer location", map[string]interface{}{"engineer_id": engineerID, "error": err.Error()})
+	}
+
+	engPayload.NormalizeBlockedCompanies()
+	engPayload.ClearStaleGithubStats(currentEng)
+
 	updatedEng, err := dao.UpdateEngineer(r.Context(), engineerID , &engPayload)
 	if err != nil {
 		return internal.NewError(http.StatusInternalServerError, "engineer.update.update_table", "failed to update engineer", err.Error())
 	}
 
+	queueModerationFlags(r.Context(), domain.ProfileTypeEngineer, updatedEng.ID, updatedEng.UserID, moderation)
+
 	internal.LogInfo("Successfully updated engineer", map[string]interface{}{"engineer": updatedEng})
 	w.WriteResponse(http.StatusOK, map[string]*domain.Engineer{"engineer": updatedEng})
 	return nil
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+// readImageUpload reads the "image" file of a multipart upload and renders its thumbnails,
+// reporting size and format problems as client errors under the given error code prefix.
+func readImageUpload(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest, spec domain.ImageSpec, code, message string) ([]*domain.Thumbnail, *internal.CustomError) {
+	data, err := r.ReadFormFile(&w, "image", domain.MaxImageUploadBytes)
+	if err != nil {
+		if errors.Is(err, internal.ErrUploadTooLarge) {
+			return nil, internal.NewError(http.StatusRequestEntityTooLarge, code+".read_file", message, err.Error())
+		}
+		return nil, internal.NewError(http.StatusBadRequest, code+".read_file", message, err.Error())
+	}
+
+	thumbnails, err := spec.Process(data)
+	if err != nil {
+		return nil, internal.NewError(http.StatusBadRequest, code+".process_image", message, err.Error())
+	}
+
+	return thumbnails, nil
+}
+
+// deleteImage removes the files of a replaced or abandoned image. Leftover files only cost
+// storage, so failing to delete them never fails the request.
+func deleteImage(ctx context.Context, image *domain.UploadedImage, userID uuid.UUID) {
+	err := domain.DeleteUploadedImage(ctx, domain.CurrentBlobStorage(), image)
+	if err != nil {
+		internal.LogInfo("Failed to delete image files", map[string]interface{}{"user_id": userID, "keys": image.Keys, "error": err.Error()})
+	}
+}
+
+// detachUploadedAvatar drops the engineer's uploaded avatar once another avatar URL has been set,
+// as its thumbnails would otherwise linger. It returns nil when there was nothing to detach.
+func detachUploadedAvatar(ctx context.Context, userID uuid.UUID, current *domain.Engineer, avatar string) (*domain.Engineer, error) {
+	if avatar == "" || avatar == current.Avatar || current.AvatarImage == nil {
+		return nil, nil
+	}
+
+	updatedEng, err := dao.ReplaceEngineerAvatar(ctx, userID, avatar, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteImage(ctx, current.AvatarImage, userID)
+	return updatedEng, nil
+}
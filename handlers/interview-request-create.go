@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleInterviewRequestCreate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	engineerID := mux.Vars(r.Request)["engineerID"]
+	interviewRequestTemplateId := os.Getenv("INTERVIEW_REQUEST_TEMPLATE_ID")
+	var interviewPayload domain.CreateInterviewRequestPayload
+
+	internal.LogInfo("Starting interview request creation", map[string]interface{}{"recruiter_id": recruiter.ID, "engineer_id": engineerID})
+
+	if !recruiter.IsMember {
+		return internal.NewError(http.StatusForbidden, "interview_request.create.check_membership", "failed to create interview request", "interview booking is reserved to members")
+	}
+
+	if recruiter.Hidden.Active(time.Now()) {
+		return internal.NewError(http.StatusForbidden, "interview_request.create.check_hidden", "failed to create interview request", "recruiter profile is hidden pending review")
+	}
+
+	err := r.DecodeJSON(&w, &interviewPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "interview_request.create.decode_body", "failed to create interview request", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(interviewPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "interview_request.create.validate_body", "failed to create interview request", err.Error())
+	}
+
+	engineer, err := dao.FindEngineerById(r.Context(), engineerID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "interview_request.create.read_engineer", "failed to create interview request", err.Error())
+	}
+
+	viewer := domain.NewViewer(r.Context())
+	if engineer == nil || !viewer.CanSeeEngineer(engineer) {
+		return internal.NewError(http.StatusNotFound, "interview_request.create.read_engineer", "failed to create interview request", "engineer not found")
+	}
+
+	interview, err := interviewPayload.NewInterviewRequest(recruiter, engineer, viewer, time.Now())
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "interview_request.create.validate_start", "failed to create interview request", err.Error())
+	}
+
+	availability, err := dao.FindAvailability(r.Context(), engineer.ID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "interview_request.create.read_availability", "failed to create interview request", err.Error())
+	}
+
+	if availability == nil {
+		return internal.NewError(http.StatusConflict, "interview_request.create.check_availability", "failed to create interview request", "engineer has not published any availability")
+	}
+
+	err = availability.CheckSlot(interview.Start, interview.End)
+	if errors.Is(err, domain.ErrSlotUnavailable) {
+		return internal.NewError(http.StatusConflict, "interview_request.create.check_availability", "failed to create interview request", err.Error())
+	}
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "interview_request.create.check_availability", "failed to create interview request", err.Error())
+	}
+
+	unlock, err := dao.LockInterviewParticipants(r.Context(), engineer.ID, recruiter.ID)
+	if errors.Is(err, domain.ErrSlotBusy) {
+		return internal.NewError(http.StatusConflict, "interview_request.create.lock_slot", "failed to create interview request", err.Error())
+	}
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "interview_request.create.lock_slot", "failed to create interview request", err.Error())
+	}
+	defer unlock()
+
+	conflict, err := dao.HasInterviewConflict(r.Context(), engineer.ID, recruiter.ID, interview.Start, interview.End, []string{domain.InterviewStatusPending, domain.InterviewStatusConfirmed}, uuid.Nil)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "interview_request.create.check_conflicts", "failed to create interview request", err.Error())
+	}
+
+	if conflict {
+		return internal.NewError(http.StatusConflict, "interview_request.create.check_conflicts", "failed to create interview request", "the slot overlaps another interview")
+	}
+
+	err = dao.InsertInterviewRequest(r.Context(), interview)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "interview_request.create.insert", "failed to create interview request", err.Error())
+	}
+
+	engineerUser, err := dao.FindUserById(r.Context(), engineer.UserID)
+	if err == nil && engineerUser != nil {
+		err = domain.SendTemplateEmail(interviewRequestTemplateId, engineerUser.Email, map[string]interface{}{
+			"recruiter_name": interview.RecruiterName,
+			"company": interview.Company,
+			"start": interview.Start.Format(time.RFC3339),
+			"end": interview.End.Format(time.RFC3339),
+			"timezone": availability.Timezone,
+			"message": interview.Message,
+			"url": fmt.Sprintf("%s/interview-requests/%s", domain.FrontendURL(), interview.ID),
+		})
+	}
+	if err != nil {
+		internal.LogInfo("Failed to notify engineer of interview request", map[string]interface{}{"interview_request_id": interview.ID, "error": err.Error()})
+	}
+
+	internal.LogInfo("Successfully created interview request", map[string]interface{}{"recruiter_id": recruiter.ID, "engineer_id": engineer.ID, "interview_request_id": interview.ID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.InterviewRequest{"interviewRequest": interview})
+	return nil
+}
@@ -0,0 +1,10 @@
+package handlers
+
+import (
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+)
+
+func HandleJobClose(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	return transitionJob(w, r, domain.JobStatusClosed, "close")
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func HandleJobCreate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	var jobPayload domain.CreateJobPayload
+
+	internal.LogInfo("Starting job creation", map[string]interface{}{"recruiter_id": recruiter.ID})
+
+	err := r.DecodeJSON(&w, &jobPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "job.create.decode_body", "failed to create job", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(jobPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "job.create.validate_body", "failed to create job", err.Error())
+	}
+
+	job := jobPayload.NewJob(recruiter)
+
+	err = dao.InsertNewJob(r.Context(), job)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "job.create.insert", "failed to create job", err.Error())
+	}
+
+	internal.LogInfo("Successfully created job", map[string]interface{}{"recruiter_id": recruiter.ID, "job_id": job.ID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Job{"job": job})
+	return nil
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+)
+
+func HandleJobList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	internal.LogInfo("Starting job list", map[string]interface{}{"user_id": r.Context().Value("userID")})
+
+	listParams, err := domain.NewListJobsParams(r.URL.Query())
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "job.list.new_query_params", "failed to list jobs", err.Error())
+	}
+
+	jobs, err := dao.ReadPublishedJobs(r.Context(), listParams)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "job.list.read_jobs", "failed to list jobs", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed jobs", map[string]interface{}{"user_id": r.Context().Value("userID")})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"jobs": jobs, "pagination": listParams.Pagination})
+	return nil
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/gorilla/mux"
+)
+
+func HandleJobPublish(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	return transitionJob(w, r, domain.JobStatusPublished, "publish")
+}
+
+// transitionJob moves a job owned by the recruiter to another status.
+func transitionJob(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest, status, action string) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	jobID := mux.Vars(r.Request)["jobID"]
+	message := fmt.Sprintf("failed to %s job", action)
+
+	internal.LogInfo(fmt.Sprintf("Starting job %s", action), map[string]interface{}{"recruiter_id": recruiter.ID, "job_id": jobID})
+
+	job, err := dao.FindJobById(r.Context(), jobID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "job."+action+".read_job", message, err.Error())
+	}
+
+	if job == nil || job.RecruiterID != recruiter.ID {
+		return internal.NewError(http.StatusNotFound, "job."+action+".read_job", message, "job not found")
+	}
+
+	if !job.CanTransition(status) {
+		return internal.NewError(http.StatusConflict, "job."+action+".check_status", message, fmt.Sprintf("job is %s", job.Status))
+	}
+
+	updatedJob, err := dao.UpdateJobStatus(r.Context(), job, status)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "job."+action+".update_table", message, err.Error())
+	}
+
+	if updatedJob == nil {
+		return internal.NewError(http.StatusConflict, "job."+action+".update_table", message, "job status changed concurrently")
+	}
+
+	internal.LogInfo(fmt.Sprintf("Successfully updated job status to %s", status), map[string]interface{}{"recruiter_id": recruiter.ID, "job_id": job.ID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Job{"job": updatedJob})
+	return nil
+}
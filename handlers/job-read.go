@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/gorilla/mux"
+)
+
+// HandleJobRead returns published jobs to anyone and unpublished ones to their recruiter only.
+// Jobs of hidden recruiters are only shown to themselves.
+func HandleJobRead(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	jobID := mux.Vars(r.Request)["jobID"]
+
+	internal.LogInfo("Starting job read", map[string]interface{}{"user_id": r.Context().Value("userID"), "job_id": jobID})
+
+	job, err := dao.FindJobById(r.Context(), jobID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "job.read.read_job", "failed to read job", err.Error())
+	}
+
+	if job == nil {
+		return internal.NewError(http.StatusNotFound, "job.read.read_job", "failed to read job", "job not found")
+	}
+
+	recruiter, ok := r.Context().Value("recruiter").(*domain.Recruiter)
+	ownJob := ok && recruiter.ID == job.RecruiterID
+
+	if job.Status != domain.JobStatusPublished && !ownJob {
+		return internal.NewError(http.StatusNotFound, "job.read.read_job", "failed to read job", "job not found")
+	}
+
+	if !ownJob {
+		jobRecruiter, err := dao.FindRecruiterById(r.Context(), job.RecruiterID.String())
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "job.read.read_recruiter", "failed to read job", err.Error())
+		}
+
+		if jobRecruiter == nil || jobRecruiter.Hidden.Active(time.Now()) {
+			return internal.NewError(http.StatusNotFound, "job.read.read_job", "failed to read job", "job not found")
+		}
+	}
+
+	internal.LogInfo("Successfully read job", map[string]interface{}{"user_id": r.Context().Value("userID"), "job_id": job.ID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Job{"job": job})
+	return nil
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/gorilla/mux"
+)
+
+func HandleJobUpdate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	jobID := mux.Vars(r.Request)["jobID"]
+	var jobPayload domain.UpdateJobPayload
+
+	internal.LogInfo("Starting job update", map[string]interface{}{"recruiter_id": recruiter.ID, "job_id": jobID})
+
+	err := r.DecodeJSON(&w, &jobPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "job.update.decode_body", "failed to update job", err.Error())
+	}
+
+	err = jobPayload.Validate(r.Context(), recruiter.ID, jobID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "job.update.validate", "failed to update job", err.Error())
+	}
+
+	updatedJob, err := dao.UpdateJob(r.Context(), jobID, &jobPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "job.update.update_table", "failed to update job", err.Error())
+	}
+
+	internal.LogInfo("Successfully updated job", map[string]interface{}{"recruiter_id": recruiter.ID, "job_id": jobID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Job{"job": updatedJob})
+	return nil
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleJSONResumeExport(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting JSON Resume export", map[string]interface{}{"user_id": userID})
+
+	engineer, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "json_resume.export.read_engineer", "failed to export resume", err.Error())
+	}
+
+	if engineer == nil {
+		return internal.NewError(http.StatusNotFound, "json_resume.export.read_engineer", "failed to export resume", "engineer not found")
+	}
+
+	user, err := dao.FindUserById(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "json_resume.export.read_user", "failed to export resume", err.Error())
+	}
+
+	email := ""
+	if user != nil {
+		email = user.Email
+	}
+
+	internal.LogInfo("Successfully exported JSON Resume", map[string]interface{}{"user_id": userID})
+	w.Header().Set("Content-Disposition", `attachment; filename="resume.json"`)
+	w.WriteResponse(http.StatusOK, domain.NewJSONResume(engineer, email))
+	return nil
+}
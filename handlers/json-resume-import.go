@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+func HandleJSONResumeImport(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	var importPayload domain.ImportJSONResumePayload
+
+	internal.LogInfo("Starting JSON Resume import", map[string]interface{}{"user_id": userID})
+
+	err := r.DecodeJSON(&w, &importPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "json_resume.import.decode_body", "failed to import resume", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(importPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "json_resume.import.validate_body", "failed to import resume", err.Error())
+	}
+
+	resume, warnings, err := domain.ParseJSONResume(importPayload.Resume)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "json_resume.import.parse_resume", "failed to import resume", err.Error())
+	}
+
+	imported := resume.Import(warnings, time.Now())
+
+	currentEng, err := dao.FindEngineerByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "json_resume.import.read_engineer", "failed to import resume", err.Error())
+	}
+
+	if currentEng == nil {
+		return importNewEngineer(w, r, userID, &importPayload, imported)
+	}
+
+	imported.WarnFixedLinks(currentEng)
+	changes := imported.Changes(currentEng)
+
+	moderation := domain.CurrentModerator().Moderate(imported.Fields.ModerationContent())
+	moderationErr := moderation.Err()
+
+	if importPayload.DryRun {
+		response := map[string]interface{}{"dryRun": true, "changes": changes, "warnings": imported.Warnings}
+		if moderationErr != nil {
+			response["error"] = moderationErr.Error()
+		}
+
+		internal.LogInfo("Successfully previewed JSON Resume import", map[string]interface{}{"user_id": userID, "changes": len(changes)})
+		w.WriteResponse(http.StatusOK, response)
+		return nil
+	}
+
+	if moderationErr != nil {
+		return internal.NewError(http.StatusBadRequest, "json_resume.import.moderate", "failed to import resume", moderationErr.Error())
+	}
+
+	err = imported.Fields.Geocode(r.Context(), currentEng)
+	if err != nil {
+		internal.LogInfo("Failed to geocode engineer location", map[string]interface{}{"user_id": userID, "error": err.Error()})
+	}
+
+	updatedEng, err := dao.UpdateEngineerByUser(r.Context(), userID, imported.Fields)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "json_resume.import.update_table", "failed to import resume", err.Error())
+	}
+
+	detachedEng, err := detachUploadedAvatar(r.Context(), userID, currentEng, imported.Fields.Avatar)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "json_resume.import.detach_avatar", "failed to import resume", err.Error())
+	}
+
+	if detachedEng != nil {
+		updatedEng = detachedEng
+	}
+
+	queueModerationFlags(r.Context(), domain.ProfileTypeEngineer, updatedEng.ID, userID, moderation)
+
+	internal.LogInfo("Successfully imported JSON Resume", map[string]interface{}{"user_id": userID, "changes": len(changes)})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"engineer": updatedEng, "changes": changes, "warnings": imported.Warnings})
+	return nil
+}
+
+// importNewEngineer creates the profile from the resume, like POST /engineers would. A dry run
+// reports the validation error instead of failing, so that the preview shows what is missing.
+func importNewEngineer(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest, userID uuid.UUID, importPayload *domain.ImportJSONResumePayload, imported *domain.ResumeImport) *internal.CustomError {
+	changes := imported.Changes(nil)
+	engPayload := imported.NewEngineerPayload(importPayload)
+
+	v := validator.New()
+	validationErr := v.Struct(engPayload)
+
+	// the fixed links of the new profile, along with everything imported
+	content := engPayload.ModerationContent()
+	importedContent := imported.Fields.ModerationContent()
+	for field, text := range importedContent.Texts {
+		content.Texts[field] = text
+	}
+	for field, link := range importedContent.Links {
+		content.Links[field] = link
+	}
+	moderation := domain.CurrentModerator().Moderate(content)
+	if validationErr == nil {
+		validationErr = moderation.Err()
+	}
+
+	if importPayload.DryRun {
+		response := map[string]interface{}{"dryRun": true, "changes": changes, "warnings": imported.Warnings, "creates": true}
+		if validationErr != nil {
+			response["error"] = validationErr.Error()
+		}
+
+		internal.LogInfo("Successfully previewed JSON Resume import", map[string]interface{}{"user_id": userID, "changes": len(changes)})
+		w.WriteResponse(http.StatusOK, response)
+		return nil
+	}
+
+	if validationErr != nil {
+		return internal.NewError(http.StatusBadRequest, "json_resume.import.validate_engineer", "failed to import resume", validationErr.Error())
+	}
+
+	eng, err := engPayload.NewEngineer(r.Context())
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "json_resume.import.create_new_engineer", "failed to import resume", err.Error())
+	}
+
+	eng.Experience = imported.Fields.Experience
+	eng.Education = imported.Fields.Education
+	eng.Projects = imported.Fields.Projects
+
+	err = eng.Geocode(r.Context())
+	if err != nil {
+		internal.LogInfo("Failed to geocode engineer location", map[string]interface{}{"engineerId": eng.ID, "error": err.Error()})
+	}
+
+	err = internal.Validate(r.Context(), eng.ID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "json_resume.import.validate_new_engineer", "failed to import resume", err.Error())
+	}
+
+	_, err = dao.InsertNewEngineer(r.Context(), eng)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "json_resume.import.insert", "failed to import resume", err.Error())
+	}
+
+	queueModerationFlags(r.Context(), domain.ProfileTypeEngineer, eng.ID, userID, moderation)
+
+	internal.LogInfo("Successfully imported JSON Resume", map[string]interface{}{"user_id": userID, "engineerId": eng.ID})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"engineer": eng, "changes": changes, "warnings": imported.Warnings})
+	return nil
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleMessageList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	conversationID := mux.Vars(r.Request)["conversationID"]
+
+	internal.LogInfo("Starting message list", map[string]interface{}{"user_id": userID, "conversation_id": conversationID})
+
+	pagination, err := domain.NewPagination(r.URL.Query(), 50, 200)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "message.list.parse_pagination", "failed to list messages", err.Error())
+	}
+
+	conversation, err := dao.FindConversationForUser(r.Context(), conversationID, userID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "message.list.read_conversation", "failed to list messages", err.Error())
+	}
+
+	if conversation == nil {
+		return internal.NewError(http.StatusNotFound, "message.list.read_conversation", "failed to list messages", "conversation not found")
+	}
+
+	messages, err := dao.ReadMessages(r.Context(), conversation.ID, pagination)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "message.list.read_messages", "failed to list messages", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed messages", map[string]interface{}{"user_id": userID, "conversation_id": conversation.ID})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"conversation": conversation, "messages": messages, "pagination": pagination})
+	return nil
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleMessageSend(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	conversationID := mux.Vars(r.Request)["conversationID"]
+	var messagePayload domain.SendMessagePayload
+
+	internal.LogInfo("Starting message send", map[string]interface{}{"user_id": userID, "conversation_id": conversationID})
+
+	err := r.DecodeJSON(&w, &messagePayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "message.send.decode_body", "failed to send message", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(messagePayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "message.send.validate_body", "failed to send message", err.Error())
+	}
+
+	conversation, err := dao.FindConversationForUser(r.Context(), conversationID, userID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "message.send.read_conversation", "failed to send message", err.Error())
+	}
+
+	if conversation == nil {
+		return internal.NewError(http.StatusNotFound, "message.send.read_conversation", "failed to send message", "conversation not found")
+	}
+
+	if userID == conversation.RecruiterUserID {
+		recruiter, err := dao.FindRecruiterById(r.Context(), conversation.RecruiterID.String())
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "message.send.read_recruiter", "failed to send message", err.Error())
+		}
+
+		if recruiter != nil && recruiter.Hidden.Active(time.Now()) {
+			return internal.NewError(http.StatusForbidden, "message.send.check_hidden", "failed to send message", "recruiter profile is hidden pending review")
+		}
+	}
+
+	blocked, err := dao.IsBlocked(r.Context(), userID, conversation.OtherParticipant(userID))
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "message.send.check_block", "failed to send message", err.Error())
+	}
+
+	if blocked {
+		return internal.NewError(http.StatusForbidden, "message.send.check_block", "failed to send message", "messaging between these users is blocked")
+	}
+
+	message := conversation.NewMessage(userID, messagePayload.Body)
+
+	err = dao.InsertMessage(r.Context(), message)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "message.send.insert", "failed to send message", err.Error())
+	}
+
+	notifyUser(r.Context(), message.RecipientID, domain.NotificationMessage, map[string]interface{}{
+		"conversation_id": conversation.ID.String(),
+		"message_id": message.ID.String(),
+		"preview": message.Preview(),
+	})
+
+	internal.LogInfo("Successfully sent message", map[string]interface{}{"user_id": userID, "conversation_id": conversation.ID, "message_id": message.ID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Message{"message": message})
+	return nil
+}
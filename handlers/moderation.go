@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"context"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+// queueModerationFlags puts what the moderator flagged in a saved profile change up for review.
+// Failing to queue the flags never fails the change itself.
+func queueModerationFlags(ctx context.Context, profileType string, profileID, userID uuid.UUID, result *domain.ModerationResult) {
+	if len(result.Flagged) == 0 {
+		return
+	}
+
+	flags := domain.NewModerationFlags(result, profileType, profileID, userID, time.Now())
+	err := dao.InsertModerationFlags(ctx, flags)
+	if err != nil {
+		internal.LogInfo("Failed to queue moderation flags", map[string]interface{}{"profile_type": profileType, "profile_id": profileID, "error": err.Error()})
+		return
+	}
+
+	internal.LogInfo("Flagged profile for moderation", map[string]interface{}{"profile_type": profileType, "profile_id": profileID, "flags": len(flags)})
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleNotificationList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	internal.LogInfo("Starting notification list", map[string]interface{}{"user_id": userID})
+
+	pagination, err := domain.NewPagination(r.URL.Query(), 20, 100)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "notification.list.parse_pagination", "failed to list notifications", err.Error())
+	}
+
+	notifications, err := dao.ReadNotificationsByUser(r.Context(), userID, unreadOnly, pagination)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "notification.list.read_notifications", "failed to list notifications", err.Error())
+	}
+
+	unreadCount, err := dao.CountUnreadNotifications(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "notification.list.count_unread", "failed to list notifications", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed notifications", map[string]interface{}{"user_id": userID})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"notifications": notifications, "unread": unreadCount, "pagination": pagination})
+	return nil
+}
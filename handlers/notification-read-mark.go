@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// HandleNotificationReadMark marks the listed notifications as read, or all of them when the
+// list is empty.
+func HandleNotificationReadMark(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	var markPayload domain.MarkNotificationsReadPayload
+
+	internal.LogInfo("Starting notification read marking", map[string]interface{}{"user_id": userID})
+
+	err := r.DecodeJSON(&w, &markPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "notification.read_mark.decode_body", "failed to mark notifications as read", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(markPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "notification.read_mark.validate_body", "failed to mark notifications as read", err.Error())
+	}
+
+	notificationIDs := make([]uuid.UUID, 0, len(markPayload.IDs))
+	for _, id := range markPayload.IDs {
+		notificationIDs = append(notificationIDs, uuid.MustParse(id))
+	}
+
+	marked, err := dao.MarkNotificationsRead(r.Context(), userID, notificationIDs)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "notification.read_mark.update", "failed to mark notifications as read", err.Error())
+	}
+
+	internal.LogInfo("Successfully marked notifications as read", map[string]interface{}{"user_id": userID, "notification_count": marked})
+	w.WriteResponse(http.StatusOK, map[string]int64{"markedRead": marked})
+	return nil
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+// streamKeepAlive is short enough for proxies not to close an idle stream.
+const streamKeepAlive = 25 * time.Second
+
+// HandleNotificationStream pushes the user's new notifications as Server-Sent Events until
+// the client disconnects.
+func HandleNotificationStream(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	flusher, ok := w.ResponseWriter.(http.Flusher)
+	if !ok {
+		return internal.NewError(http.StatusInternalServerError, "notification.stream.flush", "failed to stream notifications", "streaming is not supported")
+	}
+
+	internal.LogInfo("Starting notification stream", map[string]interface{}{"user_id": userID})
+
+	notifications, unsubscribe := domain.CurrentNotificationBroker().Subscribe(userID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 5000\n\n")
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			internal.LogInfo("Closed notification stream", map[string]interface{}{"user_id": userID})
+			return nil
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case notification, open := <-notifications:
+			if !open {
+				return nil
+			}
+
+			data, err := json.Marshal(notification)
+			if err != nil {
+				internal.LogInfo("Failed to encode notification", map[string]interface{}{"user_id": userID, "error": err.Error()})
+				continue
+			}
+
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", notification.ID, notification.Type, data)
+			flusher.Flush()
+		}
+	}
+}
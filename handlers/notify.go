@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"context"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+// notifyUser stores a notification and pushes it to the user's open streams. Failing to notify
+// never fails the request that triggered it.
+func notifyUser(ctx context.Context, userID uuid.UUID, notificationType string, data map[string]interface{}) {
+	notification := domain.NewNotification(userID, notificationType, data)
+
+	err := dao.InsertNotification(ctx, notification)
+	if err != nil {
+		internal.LogInfo("Failed to store notification", map[string]interface{}{"user_id": userID, "type": notificationType, "error": err.Error()})
+		return
+	}
+
+	domain.CurrentNotificationBroker().Publish(notification)
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+)
+
+func HandleRecruiterContactRequestList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+
+	internal.LogInfo("Starting recruiter contact request list", map[string]interface{}{"recruiter_id": recruiter.ID})
+
+	contactRequests, err := dao.ReadContactRequestsByRecruiter(r.Context(), recruiter.ID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "recruiter.contact_request.list.read_requests", "failed to list contact requests", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed recruiter contact requests", map[string]interface{}{"recruiter_id": recruiter.ID})
+	w.WriteResponse(http.StatusOK, map[string][]*domain.ContactRequest{"contactRequests": contactRequests})
+	return nil
+}
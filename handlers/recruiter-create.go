@@ -26,6 +26,12 @@ func HandleRecruiterCreate(w internal.EnhancedResponseWriter, r *internal.Enhanc
 		return internal.NewError(http.StatusBadRequest, "recruiter.create.validate_body", "failed to create new recruiter", err.Error())
 	}
 
+	moderation := domain.CurrentModerator().Moderate(recruiterPayload.ModerationContent())
+	err = moderation.Err()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "recruiter.create.moderate", "failed to create new recruiter", err.Error())
+	}
+
 	recruiter, err := recruiterPayload.NewRecruiter(r.Context())
 	if err != nil {
 		return internal.NewError(http.StatusInternalServerError, "recruiter.create.create_new_recruiter", "failed to create new recruiter", err.Error())
@@ -41,6 +47,8 @@ func HandleRecruiterCreate(w internal.EnhancedResponseWriter, r *internal.Enhanc
 		return internal.NewError(http.StatusInternalServerError, "recruiter.create.insert", "failed to create new recruiter", err.Error())
 	}
 
+	queueModerationFlags(r.Context(), domain.ProfileTypeRecruiter, recruiter.ID, recruiter.UserID, moderation)
+
 	internal.LogInfo("Successfully created new recruiter", map[string]interface{}{"recruiterId": recruiter.ID})
 	w.WriteResponse(http.StatusOK, map[string]uuid.UUID{"recruiterId": recruiter.ID})
 	return nil
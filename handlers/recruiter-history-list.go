@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleRecruiterHistoryList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting recruiter history list", map[string]interface{}{"user_id": userID})
+
+	pagination, err := domain.NewPagination(r.URL.Query(), 20, 100)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "recruiter.history.list.parse_pagination", "failed to list profile history", err.Error())
+	}
+
+	recruiter, err := dao.FindRecruiterByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "recruiter.history.list.read_recruiter", "failed to list profile history", err.Error())
+	}
+
+	if recruiter == nil {
+		return internal.NewError(http.StatusNotFound, "recruiter.history.list.read_recruiter", "failed to list profile history", "recruiter not found")
+	}
+
+	revisions, err := dao.ReadProfileHistory(r.Context(), domain.ProfileTypeRecruiter, recruiter.ID, pagination)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "recruiter.history.list.read_history", "failed to list profile history", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed recruiter history", map[string]interface{}{"user_id": userID})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"version": recruiter.Version, "history": revisions, "pagination": pagination})
+	return nil
+}
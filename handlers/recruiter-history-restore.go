@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleRecruiterHistoryRestore(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting recruiter history restore", map[string]interface{}{"user_id": userID, "version": mux.Vars(r.Request)["version"]})
+
+	version, err := strconv.Atoi(mux.Vars(r.Request)["version"])
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "recruiter.history.restore.parse_version", "failed to restore profile version", err.Error())
+	}
+
+	restoredRecruiter, err := dao.RestoreRecruiterVersion(r.Context(), userID, version)
+	if errors.Is(err, domain.ErrUnknownProfileVersion) {
+		return internal.NewError(http.StatusNotFound, "recruiter.history.restore.read_version", "failed to restore profile version", err.Error())
+	}
+	if errors.Is(err, domain.ErrProfileVersionChanged) {
+		return internal.NewError(http.StatusConflict, "recruiter.history.restore.update_table", "failed to restore profile version", err.Error())
+	}
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "recruiter.history.restore.update_table", "failed to restore profile version", err.Error())
+	}
+
+	if restoredRecruiter == nil {
+		return internal.NewError(http.StatusNotFound, "recruiter.history.restore.read_recruiter", "failed to restore profile version", "recruiter not found")
+	}
+
+	internal.LogInfo("Successfully restored recruiter version", map[string]interface{}{"user_id": userID, "version": version})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Recruiter{"recruiter": restoredRecruiter})
+	return nil
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+)
+
+func HandleRecruiterInterviewRequestList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+
+	internal.LogInfo("Starting recruiter interview request list", map[string]interface{}{"recruiter_id": recruiter.ID})
+
+	interviews, err := dao.ReadInterviewRequestsByRecruiter(r.Context(), recruiter.ID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "recruiter.interview_request.list.read_requests", "failed to list interview requests", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed recruiter interview requests", map[string]interface{}{"recruiter_id": recruiter.ID})
+	w.WriteResponse(http.StatusOK, map[string][]*domain.InterviewRequest{"interviewRequests": interviews})
+	return nil
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+)
+
+func HandleRecruiterJobList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+
+	internal.LogInfo("Starting recruiter job list", map[string]interface{}{"recruiter_id": recruiter.ID})
+
+	jobs, err := dao.ReadJobsByRecruiter(r.Context(), recruiter.ID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "recruiter.job.list.read_jobs", "failed to list jobs", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed recruiter jobs", map[string]interface{}{"recruiter_id": recruiter.ID})
+	w.WriteResponse(http.StatusOK, map[string][]*domain.Job{"jobs": jobs})
+	return nil
+}
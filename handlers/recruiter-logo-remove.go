@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleRecruiterLogoRemove(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting recruiter logo removal", map[string]interface{}{"user_id": userID})
+
+	currentRecruiter, err := dao.FindRecruiterByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "recruiter.logo.remove.read_recruiter", "failed to remove logo", err.Error())
+	}
+
+	if currentRecruiter == nil {
+		return internal.NewError(http.StatusNotFound, "recruiter.logo.remove.read_recruiter", "failed to remove logo", "recruiter not found")
+	}
+
+	updatedRecruiter, err := dao.ReplaceRecruiterLogo(r.Context(), userID, "", nil)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "recruiter.logo.remove.update_table", "failed to remove logo", err.Error())
+	}
+
+	if updatedRecruiter == nil {
+		return internal.NewError(http.StatusNotFound, "recruiter.logo.remove.update_table", "failed to remove logo", "recruiter not found")
+	}
+
+	if currentRecruiter.LogoImage != nil {
+		deleteImage(r.Context(), currentRecruiter.LogoImage, userID)
+	}
+
+	internal.LogInfo("Successfully removed recruiter logo", map[string]interface{}{"user_id": userID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Recruiter{"recruiter": updatedRecruiter})
+	return nil
+}
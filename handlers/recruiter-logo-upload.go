@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+func HandleRecruiterLogoUpload(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting recruiter logo upload", map[string]interface{}{"user_id": userID})
+
+	currentRecruiter, err := dao.FindRecruiterByUser(r.Context(), userID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "recruiter.logo.upload.read_recruiter", "failed to upload logo", err.Error())
+	}
+
+	if currentRecruiter == nil {
+		return internal.NewError(http.StatusNotFound, "recruiter.logo.upload.read_recruiter", "failed to upload logo", "recruiter not found")
+	}
+
+	thumbnails, customErr := readImageUpload(w, r, domain.LogoImageSpec, "recruiter.logo.upload", "failed to upload logo")
+	if customErr != nil {
+		return customErr
+	}
+
+	logoURL, uploaded, err := domain.LogoImageSpec.Store(r.Context(), domain.CurrentBlobStorage(), currentRecruiter.ID, thumbnails)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "recruiter.logo.upload.store_image", "failed to upload logo", err.Error())
+	}
+
+	updatedRecruiter, err := dao.ReplaceRecruiterLogo(r.Context(), userID, logoURL, uploaded)
+	if err != nil || updatedRecruiter == nil {
+		deleteImage(r.Context(), uploaded, userID)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "recruiter.logo.upload.update_table", "failed to upload logo", err.Error())
+		}
+		return internal.NewError(http.StatusNotFound, "recruiter.logo.upload.update_table", "failed to upload logo", "recruiter not found")
+	}
+
+	if currentRecruiter.LogoImage != nil {
+		deleteImage(r.Context(), currentRecruiter.LogoImage, userID)
+	}
+
+	internal.LogInfo("Successfully uploaded recruiter logo", map[string]interface{}{"user_id": userID, "logo": logoURL})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Recruiter{"recruiter": updatedRecruiter})
+	return nil
+}
@@ -27,11 +27,19 @@ func HandleRecruiterUpdate(w internal.EnhancedResponseWriter, r *internal.Enhanc
 		return internal.NewError(http.StatusBadRequest, "recruiter.udpate.validate", "failed to update recruiter", err.Error())
 	}
 
+	moderation := domain.CurrentModerator().Moderate(recruiterPayload.ModerationContent())
+	err = moderation.Err()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "recruiter.update.moderate", "failed to update recruiter", err.Error())
+	}
+
 	udpatedRecruiter, err := dao.UpdateRecruiter(r.Context(), recruiterID , &recruiterPayload)
 	if err != nil {
 		return internal.NewError(http.StatusInternalServerError, "recruiter.update.update_table", "failed to update recruiter", err.Error())
 	}
 
+	queueModerationFlags(r.Context(), domain.ProfileTypeRecruiter, udpatedRecruiter.ID, udpatedRecruiter.UserID, moderation)
+
 	internal.LogInfo("Successfully updated recruiter", map[string]interface{}{"recruiter": udpatedRecruiter})
 	w.WriteResponse(http.StatusOK, map[string]*domain.Recruiter{"recruiter": udpatedRecruiter})
 	return nil
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+func HandleReportCreate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+	var reportPayload domain.ReportProfilePayload
+
+	internal.LogInfo("Starting profile report", map[string]interface{}{"user_id": userID})
+
+	err := r.DecodeJSON(&w, &reportPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "report.create.decode_body", "failed to report profile", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(reportPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "report.create.validate_body", "failed to report profile", err.Error())
+	}
+
+	var profileID, profileUserID uuid.UUID
+	switch reportPayload.ProfileType {
+	case domain.ProfileTypeEngineer:
+		engineer, err := dao.FindEngineerById(r.Context(), reportPayload.ProfileID)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "report.create.read_profile", "failed to report profile", err.Error())
+		}
+		if engineer == nil {
+			return internal.NewError(http.StatusNotFound, "report.create.read_profile", "failed to report profile", "engineer not found")
+		}
+		profileID, profileUserID = engineer.ID, engineer.UserID
+	case domain.ProfileTypeRecruiter:
+		recruiter, err := dao.FindRecruiterById(r.Context(), reportPayload.ProfileID)
+		if err != nil {
+			return internal.NewError(http.StatusInternalServerError, "report.create.read_profile", "failed to report profile", err.Error())
+		}
+		if recruiter == nil {
+			return internal.NewError(http.StatusNotFound, "report.create.read_profile", "failed to report profile", "recruiter not found")
+		}
+		profileID, profileUserID = recruiter.ID, recruiter.UserID
+	}
+
+	if profileUserID == userID {
+		return internal.NewError(http.StatusBadRequest, "report.create.read_profile", "failed to report profile", "users cannot report their own profile")
+	}
+
+	now := time.Now()
+	newReport := domain.NewReportFlag(reportPayload.ProfileType, profileID, profileUserID, userID, reportPayload.Reason, now)
+	report, err := dao.InsertReport(r.Context(), newReport)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "report.create.insert", "failed to report profile", err.Error())
+	}
+
+	// a repeated report doesn't count twice towards hiding the profile
+	if report.ID == newReport.ID {
+		hideReportedProfile(r, report, domain.ReportSettingsFromEnv(), now)
+	}
+
+	internal.LogInfo("Successfully reported profile", map[string]interface{}{"user_id": userID, "profile_type": report.ProfileType, "profile_id": report.ProfileID})
+	w.WriteResponse(http.StatusOK, map[string]uuid.UUID{"reportId": report.ID})
+	return nil
+}
+
+// hideReportedProfile hides the profile once enough users reported it, until an admin reviews the
+// reports or the hide runs out. Failing to hide it never fails the report, which is stored.
+func hideReportedProfile(r *internal.EnhancedRequest, report *domain.ModerationFlag, settings domain.ReportSettings, now time.Time) {
+	count, err := dao.CountOpenReports(r.Context(), report.ProfileID)
+	if err != nil {
+		internal.LogInfo("Failed to count profile reports", map[string]interface{}{"profile_id": report.ProfileID, "error": err.Error()})
+		return
+	}
+
+	if count < int64(settings.HideThreshold) {
+		return
+	}
+
+	hidden, err := dao.HideReportedProfile(r.Context(), report.ProfileType, report.ProfileID, domain.NewProfileHide(domain.ProfileHiddenByReports, now, settings.HideFor))
+	if err != nil {
+		internal.LogInfo("Failed to hide reported profile", map[string]interface{}{"profile_id": report.ProfileID, "error": err.Error()})
+		return
+	}
+
+	if hidden {
+		internal.LogInfo("Hid reported profile", map[string]interface{}{"profile_type": report.ProfileType, "profile_id": report.ProfileID, "reports": count})
+	}
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func HandleSavedSearchCreate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	var searchPayload domain.CreateSavedSearchPayload
+
+	internal.LogInfo("Starting saved search creation", map[string]interface{}{"recruiter_id": recruiter.ID})
+
+	if !recruiter.IsMember {
+		return internal.NewError(http.StatusForbidden, "saved_search.create.check_membership", "failed to create saved search", "saved searches are reserved to members")
+	}
+
+	err := r.DecodeJSON(&w, &searchPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "saved_search.create.decode_body", "failed to create saved search", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(searchPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "saved_search.create.validate_body", "failed to create saved search", err.Error())
+	}
+
+	search, err := searchPayload.NewSavedSearch(r.Context(), recruiter.ID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "saved_search.create.parse_filters", "failed to create saved search", err.Error())
+	}
+
+	err = dao.InsertNewSavedSearch(r.Context(), search)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "saved_search.create.insert", "failed to create saved search", err.Error())
+	}
+
+	internal.LogInfo("Successfully created saved search", map[string]interface{}{"recruiter_id": recruiter.ID, "search_id": search.ID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.SavedSearch{"search": search})
+	return nil
+}
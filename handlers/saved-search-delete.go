@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/gorilla/mux"
+)
+
+func HandleSavedSearchDelete(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	searchID := mux.Vars(r.Request)["searchID"]
+
+	internal.LogInfo("Starting saved search deletion", map[string]interface{}{"recruiter_id": recruiter.ID, "search_id": searchID})
+
+	deleted, err := dao.DeleteSavedSearch(r.Context(), recruiter.ID, searchID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "saved_search.delete.delete", "failed to delete saved search", err.Error())
+	}
+
+	if !deleted {
+		return internal.NewError(http.StatusNotFound, "saved_search.delete.delete", "failed to delete saved search", "saved search not found")
+	}
+
+	internal.LogInfo("Successfully deleted saved search", map[string]interface{}{"recruiter_id": recruiter.ID, "search_id": searchID})
+	w.WriteResponse(http.StatusOK, map[string]string{"searchId": searchID})
+	return nil
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+)
+
+func HandleSavedSearchList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+
+	internal.LogInfo("Starting saved search list", map[string]interface{}{"recruiter_id": recruiter.ID})
+
+	searches, err := dao.ReadSavedSearchesByRecruiter(r.Context(), recruiter.ID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "saved_search.list.read_searches", "failed to list saved searches", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed saved searches", map[string]interface{}{"recruiter_id": recruiter.ID})
+	w.WriteResponse(http.StatusOK, map[string][]*domain.SavedSearch{"searches": searches})
+	return nil
+}
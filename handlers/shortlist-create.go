@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func HandleShortlistCreate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	var shortlistPayload domain.CreateShortlistPayload
+
+	internal.LogInfo("Starting shortlist creation", map[string]interface{}{"recruiter_id": recruiter.ID})
+
+	err := r.DecodeJSON(&w, &shortlistPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.create.decode_body", "failed to create shortlist", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(shortlistPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.create.validate_body", "failed to create shortlist", err.Error())
+	}
+
+	shortlist := shortlistPayload.NewShortlist(recruiter.ID)
+
+	err = dao.InsertNewShortlist(r.Context(), shortlist)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.create.insert", "failed to create shortlist", err.Error())
+	}
+
+	internal.LogInfo("Successfully created shortlist", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlist.ID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Shortlist{"shortlist": shortlist})
+	return nil
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/gorilla/mux"
+)
+
+func HandleShortlistDelete(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	shortlistID := mux.Vars(r.Request)["shortlistID"]
+
+	internal.LogInfo("Starting shortlist deletion", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID})
+
+	deleted, err := dao.DeleteShortlist(r.Context(), recruiter.ID, shortlistID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.delete.delete", "failed to delete shortlist", err.Error())
+	}
+
+	if !deleted {
+		return internal.NewError(http.StatusNotFound, "shortlist.delete.delete", "failed to delete shortlist", "shortlist not found")
+	}
+
+	internal.LogInfo("Successfully deleted shortlist", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID})
+	w.WriteResponse(http.StatusOK, map[string]string{"shortlistId": shortlistID})
+	return nil
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+func HandleShortlistEntryAdd(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	shortlistID := mux.Vars(r.Request)["shortlistID"]
+	var entryPayload domain.AddShortlistEntryPayload
+
+	internal.LogInfo("Starting shortlist entry addition", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID})
+
+	err := r.DecodeJSON(&w, &entryPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.entry.add.decode_body", "failed to add engineer to shortlist", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(entryPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.entry.add.validate_body", "failed to add engineer to shortlist", err.Error())
+	}
+
+	entry, err := entryPayload.NewShortlistEntry()
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.entry.add.create_entry", "failed to add engineer to shortlist", err.Error())
+	}
+
+	shortlist, err := dao.FindShortlist(r.Context(), recruiter.ID, shortlistID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.entry.add.read_shortlist", "failed to add engineer to shortlist", err.Error())
+	}
+
+	if shortlist == nil {
+		return internal.NewError(http.StatusNotFound, "shortlist.entry.add.read_shortlist", "failed to add engineer to shortlist", "shortlist not found")
+	}
+
+	if shortlist.HasEngineer(entry.EngineerID) {
+		return internal.NewError(http.StatusConflict, "shortlist.entry.add.check_duplicate", "failed to add engineer to shortlist", "engineer already in shortlist")
+	}
+
+	engineer, err := dao.FindEngineerById(r.Context(), entryPayload.EngineerID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.entry.add.read_engineer", "failed to add engineer to shortlist", err.Error())
+	}
+
+	if engineer == nil || !domain.NewViewer(r.Context()).CanSeeEngineer(engineer) {
+		return internal.NewError(http.StatusNotFound, "shortlist.entry.add.read_engineer", "failed to add engineer to shortlist", "engineer not found")
+	}
+
+	updatedShortlist, err := dao.AddShortlistEntry(r.Context(), recruiter.ID, shortlist.ID, entry)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.entry.add.update_table", "failed to add engineer to shortlist", err.Error())
+	}
+
+	if updatedShortlist == nil {
+		return internal.NewError(http.StatusConflict, "shortlist.entry.add.update_table", "failed to add engineer to shortlist", "engineer already in shortlist")
+	}
+
+	internal.LogInfo("Successfully added engineer to shortlist", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID, "engineer_id": entry.EngineerID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Shortlist{"shortlist": updatedShortlist})
+	return nil
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleShortlistEntryRemove(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	params := mux.Vars(r.Request)
+	shortlistID := params["shortlistID"]
+	engineerID := params["engineerID"]
+
+	internal.LogInfo("Starting shortlist entry removal", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID, "engineer_id": engineerID})
+
+	parsedShortlistID, err := uuid.Parse(shortlistID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.entry.remove.validate_params", "failed to remove engineer from shortlist", err.Error())
+	}
+
+	parsedEngineerID, err := uuid.Parse(engineerID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.entry.remove.validate_params", "failed to remove engineer from shortlist", err.Error())
+	}
+
+	shortlist, err := dao.RemoveShortlistEntry(r.Context(), recruiter.ID, parsedShortlistID, parsedEngineerID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.entry.remove.update_table", "failed to remove engineer from shortlist", err.Error())
+	}
+
+	if shortlist == nil {
+		return internal.NewError(http.StatusNotFound, "shortlist.entry.remove.update_table", "failed to remove engineer from shortlist", "shortlist entry not found")
+	}
+
+	internal.LogInfo("Successfully removed engineer from shortlist", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID, "engineer_id": engineerID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Shortlist{"shortlist": shortlist})
+	return nil
+}
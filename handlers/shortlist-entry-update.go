@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleShortlistEntryUpdate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	params := mux.Vars(r.Request)
+	shortlistID := params["shortlistID"]
+	engineerID := params["engineerID"]
+	var entryPayload domain.UpdateShortlistEntryPayload
+
+	internal.LogInfo("Starting shortlist entry update", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID, "engineer_id": engineerID})
+
+	parsedShortlistID, err := uuid.Parse(shortlistID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.entry.update.validate_params", "failed to update shortlist entry", err.Error())
+	}
+
+	parsedEngineerID, err := uuid.Parse(engineerID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.entry.update.validate_params", "failed to update shortlist entry", err.Error())
+	}
+
+	err = r.DecodeJSON(&w, &entryPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.entry.update.decode_body", "failed to update shortlist entry", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(entryPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.entry.update.validate_body", "failed to update shortlist entry", err.Error())
+	}
+
+	shortlist, err := dao.UpdateShortlistEntry(r.Context(), recruiter.ID, parsedShortlistID, parsedEngineerID, &entryPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.entry.update.update_table", "failed to update shortlist entry", err.Error())
+	}
+
+	if shortlist == nil {
+		return internal.NewError(http.StatusNotFound, "shortlist.entry.update.update_table", "failed to update shortlist entry", "shortlist entry not found")
+	}
+
+	internal.LogInfo("Successfully updated shortlist entry", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID, "engineer_id": engineerID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Shortlist{"shortlist": shortlist})
+	return nil
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+)
+
+func HandleShortlistList(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+
+	internal.LogInfo("Starting shortlist list", map[string]interface{}{"recruiter_id": recruiter.ID})
+
+	shortlists, err := dao.ReadShortlistsByRecruiter(r.Context(), recruiter.ID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.list.read_shortlists", "failed to list shortlists", err.Error())
+	}
+
+	internal.LogInfo("Successfully listed shortlists", map[string]interface{}{"recruiter_id": recruiter.ID})
+	w.WriteResponse(http.StatusOK, map[string][]*domain.Shortlist{"shortlists": shortlists})
+	return nil
+}
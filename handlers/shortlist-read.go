@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/gorilla/mux"
+)
+
+func HandleShortlistRead(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	shortlistID := mux.Vars(r.Request)["shortlistID"]
+
+	internal.LogInfo("Starting shortlist read", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID})
+
+	shortlist, err := dao.FindShortlist(r.Context(), recruiter.ID, shortlistID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.read.read_by_id", "failed to read shortlist", err.Error())
+	}
+
+	if shortlist == nil {
+		return internal.NewError(http.StatusNotFound, "shortlist.read.read_by_id", "failed to read shortlist", "shortlist not found")
+	}
+
+	entries, err := hydrateShortlistEntries(r.Context(), domain.NewViewer(r.Context()), shortlist)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.read.hydrate_entries", "failed to read shortlist", err.Error())
+	}
+
+	internal.LogInfo("Successfully read shortlist", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID})
+	w.WriteResponse(http.StatusOK, map[string]interface{}{"shortlist": shortlist, "entries": entries})
+	return nil
+}
+
+// hydrateShortlistEntries attaches each engineer as the viewer is allowed to see it, so that
+// non-members get the same concealment as on the engineer endpoints.
+func hydrateShortlistEntries(ctx context.Context, viewer *domain.Viewer, shortlist *domain.Shortlist) ([]*domain.HydratedShortlistEntry, error) {
+	entries := make([]*domain.HydratedShortlistEntry, 0, len(shortlist.Entries))
+
+	for _, entry := range shortlist.Entries {
+		hydrated := &domain.HydratedShortlistEntry{ShortlistEntry: entry}
+
+		engineer, err := dao.FindEngineerById(ctx, entry.EngineerID.String())
+		if err != nil {
+			return nil, err
+		}
+
+		if engineer == nil || !viewer.CanSeeEngineer(engineer) {
+			hydrated.Unavailable = true
+			entries = append(entries, hydrated)
+			continue
+		}
+
+		hydrated.Engineer, err = viewer.EngineerView(engineer)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, hydrated)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleShortlistUpdate(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	recruiter := r.Context().Value("recruiter").(*domain.Recruiter)
+	shortlistID := mux.Vars(r.Request)["shortlistID"]
+	var shortlistPayload domain.UpdateShortlistPayload
+
+	internal.LogInfo("Starting shortlist update", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID})
+
+	parsedShortlistID, err := uuid.Parse(shortlistID)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.update.validate_params", "failed to update shortlist", err.Error())
+	}
+
+	err = r.DecodeJSON(&w, &shortlistPayload)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.update.decode_body", "failed to update shortlist", err.Error())
+	}
+
+	v := validator.New()
+	err = v.Struct(shortlistPayload)
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "shortlist.update.validate_body", "failed to update shortlist", err.Error())
+	}
+
+	shortlist, err := dao.RenameShortlist(r.Context(), recruiter.ID, parsedShortlistID, shortlistPayload.Name)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "shortlist.update.update_table", "failed to update shortlist", err.Error())
+	}
+
+	if shortlist == nil {
+		return internal.NewError(http.StatusNotFound, "shortlist.update.update_table", "failed to update shortlist", "shortlist not found")
+	}
+
+	internal.LogInfo("Successfully updated shortlist", map[string]interface{}{"recruiter_id": recruiter.ID, "shortlist_id": shortlistID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Shortlist{"shortlist": shortlist})
+	return nil
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"strconv"
+)
+
+// HandleSitemapRead lists the profiles anonymous visitors can see, for search engines.
+func HandleSitemapRead(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	internal.LogInfo("Starting sitemap read", map[string]interface{}{})
+
+	anonymous := &domain.Viewer{}
+	engineers, err := dao.ReadSitemapEngineers(r.Context(), anonymous.EngineerListingFilter("", false))
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "sitemap.read.read_engineers", "failed to read sitemap", err.Error())
+	}
+
+	sitemap, err := domain.NewEngineerSitemap(engineers)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "sitemap.read.render", "failed to read sitemap", err.Error())
+	}
+
+	internal.LogInfo("Successfully read sitemap", map[string]interface{}{"urls": len(engineers)})
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(sitemap)))
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	w.Write(sitemap)
+	return nil
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleUserBlock(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting user block", map[string]interface{}{"user_id": userID, "blocked_id": mux.Vars(r.Request)["userID"]})
+
+	blockedID, err := uuid.Parse(mux.Vars(r.Request)["userID"])
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "user.block.parse_id", "failed to block user", err.Error())
+	}
+
+	if blockedID == userID {
+		return internal.NewError(http.StatusBadRequest, "user.block.parse_id", "failed to block user", "users cannot block themselves")
+	}
+
+	block, err := domain.NewBlock(userID, blockedID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "user.block.new_block", "failed to block user", err.Error())
+	}
+
+	err = dao.InsertBlock(r.Context(), block)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "user.block.insert", "failed to block user", err.Error())
+	}
+
+	internal.LogInfo("Successfully blocked user", map[string]interface{}{"user_id": userID, "blocked_id": blockedID})
+	w.WriteResponse(http.StatusOK, map[string]*domain.Block{"block": block})
+	return nil
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func HandleUserUnblock(w internal.EnhancedResponseWriter, r *internal.EnhancedRequest) *internal.CustomError {
+	userID := r.Context().Value("userID").(uuid.UUID)
+
+	internal.LogInfo("Starting user unblock", map[string]interface{}{"user_id": userID, "blocked_id": mux.Vars(r.Request)["userID"]})
+
+	blockedID, err := uuid.Parse(mux.Vars(r.Request)["userID"])
+	if err != nil {
+		return internal.NewError(http.StatusBadRequest, "user.unblock.parse_id", "failed to unblock user", err.Error())
+	}
+
+	deleted, err := dao.DeleteBlock(r.Context(), userID, blockedID)
+	if err != nil {
+		return internal.NewError(http.StatusInternalServerError, "user.unblock.delete", "failed to unblock user", err.Error())
+	}
+
+	if !deleted {
+		return internal.NewError(http.StatusNotFound, "user.unblock.delete", "failed to unblock user", "block not found")
+	}
+
+	internal.LogInfo("Successfully unblocked user", map[string]interface{}{"user_id": userID, "blocked_id": blockedID})
+	w.WriteResponse(http.StatusOK, map[string]string{"userId": blockedID.String()})
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 )
@@ -93,6 +94,67 @@ func (r *EnhancedRequest) DecodeJSON(w *EnhancedResponseWriter, dst interface{})
 	return nil
 }
 
+var ErrUploadTooLarge = errors.New("uploaded file is too large")
+
+// ReadFormFile reads the named file from a multipart body, returning ErrUploadTooLarge when it
+// exceeds maxBytes. Other parts of the form are skipped.
+func (r *EnhancedRequest) ReadFormFile(w *EnhancedResponseWriter, field string, maxBytes int64) ([]byte, error) {
+	// leaves room for the multipart boundaries and headers around the file
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes+64*1024)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, errors.New("body must be multipart/form-data")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, fmt.Errorf("body must contain a %s file", field)
+		}
+		if err != nil {
+			return nil, uploadError(err)
+		}
+
+		if part.FormName() != field || part.FileName() == "" {
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, maxBytes+1))
+		if err != nil {
+			return nil, uploadError(err)
+		}
+
+		if int64(len(data)) > maxBytes {
+			return nil, ErrUploadTooLarge
+		}
+
+		return data, nil
+	}
+}
+
+func uploadError(err error) error {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return ErrUploadTooLarge
+	}
+	return err
+}
+
+// ClientIP returns the address of the client, trusting the first X-Forwarded-For entry set by
+// the load balancer.
+func (r *EnhancedRequest) ClientIP() string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 func (w EnhancedResponseWriter) WriteResponse(status int, data any) {
 	js, err := json.MarshalIndent(data, "", "\t")
 	if err != nil {
@@ -1,16 +1,21 @@
 package main
 
 import (
+	"angular-talents-backend/dao"
 	"angular-talents-backend/db"
+	"angular-talents-backend/domain"
 	"angular-talents-backend/handlers"
 	"angular-talents-backend/internal"
 	"angular-talents-backend/middlewares"
+	"angular-talents-backend/workers"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"sync"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
@@ -43,12 +48,46 @@ func main() {
 
 	db.InitiateDB()
 
+	if db.Database != nil {
+		if err := dao.EnsureIndexes(context.Background()); err != nil {
+			log.Println("Failed to create indexes:", err)
+		}
+	}
+
+	domain.SetGeocoder(domain.NewGeocoderFromEnv())
+	domain.SetBlobStorage(domain.NewBlobStorageFromEnv())
+	domain.SetGithubClient(domain.NewGithubClientFromEnv())
+
+	moderator, err := domain.NewModeratorFromEnv()
+	if err != nil {
+		log.Fatal("Failed to load moderation word lists: ", err)
+	}
+	domain.SetModerator(moderator)
+
+	if localStorage, ok := domain.CurrentBlobStorage().(*domain.LocalBlobStorage); ok {
+		r.PathPrefix(localStorage.PathPrefix()).Handler(localStorage.Handler()).Methods("GET", "HEAD")
+	}
+
+	if db.Database != nil && os.Getenv("NOTIFICATION_BROKER") == "changestream" {
+		domain.SetNotificationBroker(dao.NewChangeStreamBroker(context.Background()))
+	}
+
+	if db.Database != nil {
+		go workers.Every(context.Background(), "saved_search_alerts", time.Hour, workers.SendSavedSearchAlerts)
+		go workers.Every(context.Background(), "message_notifications", 10*time.Minute, workers.SendMessageNotifications)
+		go workers.Every(context.Background(), "github_stats", time.Hour, workers.RefreshGithubStats)
+		go workers.Every(context.Background(), "completeness_nudges", 24*time.Hour, workers.SendCompletenessNudges)
+		go workers.Every(context.Background(), "duplicate_accounts", 24*time.Hour, workers.FlagDuplicateAccounts)
+	}
+
 	r.Handle("/health", internal.EnhancedHandler(handlers.HandleHealth)).Methods("GET")
 	r.Handle("/email", internal.EnhancedHandler(handlers.HandleEmail)).Methods("GET")
 	r.Handle("/sign-up", internal.EnhancedHandler(handlers.HandleSignUp)).Methods("POST")
   r.Handle("/login", internal.EnhancedHandler(handlers.HandleLogin)).Methods("POST")
 	r.Handle("/verify/{userID}/{verificationCode}", internal.EnhancedHandler(handlers.HandleEmailVerify)).Methods("GET")
   r.Handle("/count", internal.EnhancedHandler(handlers.HandleCount)).Methods("GET")
+	r.Handle("/e/{slug}", internal.EnhancedHandler(handlers.HandleEngineerSlugRead)).Methods("GET")
+	r.Handle("/sitemap.xml", internal.EnhancedHandler(handlers.HandleSitemapRead)).Methods("GET")
 
 	authenticatedRoutes := r.NewRoute().Subrouter()
 
@@ -57,19 +96,89 @@ func main() {
 
 	authenticatedRoutes.Handle("/engineers/me", internal.EnhancedHandler(handlers.HandleAuthenticatedEngineerUpdate)).Methods("PUT")
 	authenticatedRoutes.Handle("/engineers", internal.EnhancedHandler(handlers.HandleEngineerCreate)).Methods("POST")
+	authenticatedRoutes.Handle("/engineers/me/avatar", internal.EnhancedHandler(handlers.HandleEngineerAvatarUpload)).Methods("PUT")
+	authenticatedRoutes.Handle("/engineers/me/avatar", internal.EnhancedHandler(handlers.HandleEngineerAvatarRemove)).Methods("DELETE")
+	authenticatedRoutes.Handle("/engineers/me/import/json-resume", internal.EnhancedHandler(handlers.HandleJSONResumeImport)).Methods("POST")
+	authenticatedRoutes.Handle("/engineers/me/export/json-resume", internal.EnhancedHandler(handlers.HandleJSONResumeExport)).Methods("GET")
+	authenticatedRoutes.Handle("/engineers/me/slug", internal.EnhancedHandler(handlers.HandleEngineerSlugUpdate)).Methods("PUT")
+	authenticatedRoutes.Handle("/engineers/me/history", internal.EnhancedHandler(handlers.HandleEngineerHistoryList)).Methods("GET")
+	authenticatedRoutes.Handle("/engineers/me/history/{version:[0-9]+}/restore", internal.EnhancedHandler(handlers.HandleEngineerHistoryRestore)).Methods("POST")
+	authenticatedRoutes.Handle("/engineers/me/stats", internal.EnhancedHandler(handlers.HandleEngineerStatsRead)).Methods("GET")
+	authenticatedRoutes.Handle("/engineers/me/availability", internal.EnhancedHandler(handlers.HandleAuthenticatedAvailabilityRead)).Methods("GET")
+	authenticatedRoutes.Handle("/engineers/me/availability", internal.EnhancedHandler(handlers.HandleAvailabilityUpdate)).Methods("PUT")
+	authenticatedRoutes.Handle("/engineers/me/interview-requests", internal.EnhancedHandler(handlers.HandleEngineerInterviewRequestList)).Methods("GET")
+	authenticatedRoutes.Handle("/engineers/me/interview-requests/{requestID}", internal.EnhancedHandler(handlers.HandleEngineerInterviewRequestRespond)).Methods("PUT")
+	authenticatedRoutes.Handle("/engineers/me/{section:experience|education|projects}", internal.EnhancedHandler(handlers.HandleEngineerSectionEntryAdd)).Methods("POST")
+	authenticatedRoutes.Handle("/engineers/me/{section:experience|education|projects}/{entryID}", internal.EnhancedHandler(handlers.HandleEngineerSectionEntryUpdate)).Methods("PUT")
+	authenticatedRoutes.Handle("/engineers/me/{section:experience|education|projects}/{entryID}", internal.EnhancedHandler(handlers.HandleEngineerSectionEntryRemove)).Methods("DELETE")
+	authenticatedRoutes.Handle("/engineers/me/contact-requests", internal.EnhancedHandler(handlers.HandleEngineerContactRequestList)).Methods("GET")
+	authenticatedRoutes.Handle("/engineers/me/contact-requests/{requestID}", internal.EnhancedHandler(handlers.HandleEngineerContactRequestRespond)).Methods("PUT")
+
+	authenticatedRoutes.Handle("/conversations", internal.EnhancedHandler(handlers.HandleConversationList)).Methods("GET")
+	authenticatedRoutes.Handle("/conversations/{conversationID}/messages", internal.EnhancedHandler(handlers.HandleMessageList)).Methods("GET")
+	authenticatedRoutes.Handle("/conversations/{conversationID}/messages", internal.EnhancedHandler(handlers.HandleMessageSend)).Methods("POST")
+	authenticatedRoutes.Handle("/conversations/{conversationID}/read", internal.EnhancedHandler(handlers.HandleConversationReadMark)).Methods("POST")
+	authenticatedRoutes.Handle("/users/{userID}/block", internal.EnhancedHandler(handlers.HandleUserBlock)).Methods("POST")
+	authenticatedRoutes.Handle("/users/{userID}/block", internal.EnhancedHandler(handlers.HandleUserUnblock)).Methods("DELETE")
+	authenticatedRoutes.Handle("/reports", internal.EnhancedHandler(handlers.HandleReportCreate)).Methods("POST")
+
+	authenticatedRoutes.Handle("/notifications", internal.EnhancedHandler(handlers.HandleNotificationList)).Methods("GET")
+	authenticatedRoutes.Handle("/notifications/read", internal.EnhancedHandler(handlers.HandleNotificationReadMark)).Methods("POST")
+	authenticatedRoutes.Handle("/notifications/stream", internal.EnhancedHandler(handlers.HandleNotificationStream)).Methods("GET")
 
 	authenticatedRoutes.Handle("/recruiters/me", internal.EnhancedHandler(handlers.HandleAuthenticatedRecruiterUpdate)).Methods("PUT")
+	authenticatedRoutes.Handle("/recruiters/me/logo", internal.EnhancedHandler(handlers.HandleRecruiterLogoUpload)).Methods("PUT")
+	authenticatedRoutes.Handle("/recruiters/me/logo", internal.EnhancedHandler(handlers.HandleRecruiterLogoRemove)).Methods("DELETE")
+	authenticatedRoutes.Handle("/recruiters/me/history", internal.EnhancedHandler(handlers.HandleRecruiterHistoryList)).Methods("GET")
+	authenticatedRoutes.Handle("/recruiters/me/history/{version:[0-9]+}/restore", internal.EnhancedHandler(handlers.HandleRecruiterHistoryRestore)).Methods("POST")
 	authenticatedRoutes.Handle("/recruiters", internal.EnhancedHandler(handlers.HandleRecruiterCreate)).Methods("POST")
 
+	recruiterRoutes := authenticatedRoutes.NewRoute().Subrouter()
+
+	recruiterRoutes.Use(middlewares.ValidateRecruiter)
+	recruiterRoutes.Handle("/recruiters/me/searches", internal.EnhancedHandler(handlers.HandleSavedSearchCreate)).Methods("POST")
+	recruiterRoutes.Handle("/recruiters/me/searches", internal.EnhancedHandler(handlers.HandleSavedSearchList)).Methods("GET")
+	recruiterRoutes.Handle("/recruiters/me/searches/{searchID}", internal.EnhancedHandler(handlers.HandleSavedSearchDelete)).Methods("DELETE")
+	recruiterRoutes.Handle("/recruiters/me/shortlists", internal.EnhancedHandler(handlers.HandleShortlistCreate)).Methods("POST")
+	recruiterRoutes.Handle("/recruiters/me/shortlists", internal.EnhancedHandler(handlers.HandleShortlistList)).Methods("GET")
+	recruiterRoutes.Handle("/recruiters/me/shortlists/{shortlistID}", internal.EnhancedHandler(handlers.HandleShortlistRead)).Methods("GET")
+	recruiterRoutes.Handle("/recruiters/me/shortlists/{shortlistID}", internal.EnhancedHandler(handlers.HandleShortlistUpdate)).Methods("PUT")
+	recruiterRoutes.Handle("/recruiters/me/shortlists/{shortlistID}", internal.EnhancedHandler(handlers.HandleShortlistDelete)).Methods("DELETE")
+	recruiterRoutes.Handle("/recruiters/me/shortlists/{shortlistID}/entries", internal.EnhancedHandler(handlers.HandleShortlistEntryAdd)).Methods("POST")
+	recruiterRoutes.Handle("/recruiters/me/shortlists/{shortlistID}/entries/{engineerID}", internal.EnhancedHandler(handlers.HandleShortlistEntryUpdate)).Methods("PUT")
+	recruiterRoutes.Handle("/recruiters/me/shortlists/{shortlistID}/entries/{engineerID}", internal.EnhancedHandler(handlers.HandleShortlistEntryRemove)).Methods("DELETE")
+	recruiterRoutes.Handle("/recruiters/me/contact-requests", internal.EnhancedHandler(handlers.HandleRecruiterContactRequestList)).Methods("GET")
+	recruiterRoutes.Handle("/engineers/{engineerID}/contact-requests", internal.EnhancedHandler(handlers.HandleContactRequestCreate)).Methods("POST")
+	recruiterRoutes.Handle("/recruiters/me/interview-requests", internal.EnhancedHandler(handlers.HandleRecruiterInterviewRequestList)).Methods("GET")
+	recruiterRoutes.Handle("/engineers/match", internal.EnhancedHandler(handlers.HandleEngineerMatch)).Methods("POST")
+	recruiterRoutes.Handle("/engineers/{engineerID}/availability", internal.EnhancedHandler(handlers.HandleAvailabilityRead)).Methods("GET")
+	recruiterRoutes.Handle("/engineers/{engineerID}/interview-requests", internal.EnhancedHandler(handlers.HandleInterviewRequestCreate)).Methods("POST")
+	recruiterRoutes.Handle("/recruiters/me/jobs", internal.EnhancedHandler(handlers.HandleRecruiterJobList)).Methods("GET")
+	recruiterRoutes.Handle("/jobs", internal.EnhancedHandler(handlers.HandleJobCreate)).Methods("POST")
+	recruiterRoutes.Handle("/jobs/{jobID}", internal.EnhancedHandler(handlers.HandleJobUpdate)).Methods("PUT")
+	recruiterRoutes.Handle("/jobs/{jobID}/publish", internal.EnhancedHandler(handlers.HandleJobPublish)).Methods("POST")
+	recruiterRoutes.Handle("/jobs/{jobID}/close", internal.EnhancedHandler(handlers.HandleJobClose)).Methods("POST")
+	recruiterRoutes.Handle("/conversations", internal.EnhancedHandler(handlers.HandleConversationCreate)).Methods("POST")
+
+	adminRoutes := authenticatedRoutes.NewRoute().Subrouter()
+
+	adminRoutes.Use(middlewares.ValidateAdmin)
+	adminRoutes.Handle("/admin/recruiters/{recruiterID}/membership", internal.EnhancedHandler(handlers.HandleAdminRecruiterMembershipUpdate)).Methods("PUT")
+	adminRoutes.Handle("/admin/moderation/flags", internal.EnhancedHandler(handlers.HandleAdminModerationFlagList)).Methods("GET")
+	adminRoutes.Handle("/admin/moderation/flags/{flagID}", internal.EnhancedHandler(handlers.HandleAdminModerationFlagReview)).Methods("PUT")
+
 	membersRoutes := r.NewRoute().Subrouter()
 
 	membersRoutes.Use(middlewares.ValidateMembership)
 	membersRoutes.Handle("/engineers", internal.EnhancedHandler(handlers.HandleEngineerList)).Methods("GET")
+	membersRoutes.Handle("/engineers/{engineerID}/resume.pdf", internal.EnhancedHandler(handlers.HandleEngineerResumeRead)).Methods("GET")
 	membersRoutes.Handle("/engineers/{engineerID}", internal.EnhancedHandler(handlers.HandleEngineerRead)).Methods("GET")
+	membersRoutes.Handle("/jobs", internal.EnhancedHandler(handlers.HandleJobList)).Methods("GET")
+	membersRoutes.Handle("/jobs/{jobID}", internal.EnhancedHandler(handlers.HandleJobRead)).Methods("GET")
 
 	withCors := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "HEAD", "OPTIONS", "POST", "PUT"},
+		AllowedMethods:   []string{"GET", "HEAD", "OPTIONS", "POST", "PUT", "DELETE"},
 		AllowedHeaders:   []string{"Authorization", "Access-Control-Allow-Headers", "Origin", "Accept", "X-Requested-With", "Content-Type", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
 		AllowCredentials: true,
 		// Enable Debugging for testing, consider disabling in production
@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+// ValidateAdmin must run after ValidateAuth. It rejects users who are not administrators.
+func ValidateAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := r.Context().Value("userID").(uuid.UUID)
+
+		user, err := dao.FindUserById(r.Context(), userID)
+		if err != nil {
+			err := internal.NewError(http.StatusInternalServerError, "admin.find_user", "failed to retrieve user", err.Error())
+			internal.WriteError(w, err)
+			return
+		}
+
+		if user == nil || !user.IsAdmin {
+			err := internal.NewError(http.StatusForbidden, "admin.check_admin", "failed to validate admin", "reserved to administrators")
+			internal.WriteError(w, err)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "isAdmin", true)
+		r = r.WithContext(ctx)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -16,6 +16,7 @@ func ValidateMembership(next http.Handler) http.Handler {
 		if authorization == "" {
 			ctx := context.WithValue(r.Context(), "userID", "")
 			ctx = context.WithValue(ctx, "isMember", false)
+			ctx = context.WithValue(ctx, "isAdmin", false)
 			r = r.WithContext(ctx)
 			next.ServeHTTP(w, r)
 			return
@@ -28,11 +29,21 @@ func ValidateMembership(next http.Handler) http.Handler {
 		if err != nil {
 			ctx := context.WithValue(r.Context(), "userID", "")
 			ctx = context.WithValue(ctx, "isMember", false)
+			ctx = context.WithValue(ctx, "isAdmin", false)
 			r = r.WithContext(ctx)
 			next.ServeHTTP(w, r)
 			return
 		}
 
+		user, err := dao.FindUserById(r.Context(), userID)
+		if err != nil {
+			err :=  internal.NewError(http.StatusInternalServerError, "membership.find_user", "failed to retrieve user", err.Error())
+			internal.WriteError(w, err)
+			return
+		}
+
+		isAdmin := user != nil && user.IsAdmin
+
 		recruiter, err := dao.FindRecruiterByUser(r.Context(), userID)
 		if err != nil {
 			err :=  internal.NewError(http.StatusInternalServerError, "membership.find_recruiter", "failed to retrieve recruiter", err.Error())
@@ -43,13 +54,27 @@ func ValidateMembership(next http.Handler) http.Handler {
 		if recruiter == nil {
 			ctx := context.WithValue(r.Context(), "userID", userID)
 			ctx = context.WithValue(ctx, "isMember", false)
+			ctx = context.WithValue(ctx, "isAdmin", isAdmin)
 			r = r.WithContext(ctx)
 			next.ServeHTTP(w, r)
 			return
 		}
 
+		contactEngineerIDs, err := dao.ReadAcceptedContactEngineerIDs(r.Context(), recruiter.ID)
+		if err != nil {
+			err :=  internal.NewError(http.StatusInternalServerError, "membership.find_contacts", "failed to retrieve recruiter contacts", err.Error())
+			internal.WriteError(w, err)
+			return
+		}
+
 		ctx := context.WithValue(r.Context(), "userID", userID)
 		ctx = context.WithValue(ctx, "isMember", recruiter.IsMember)
+		ctx = context.WithValue(ctx, "isAdmin", isAdmin)
+		ctx = context.WithValue(ctx, "contactEngineerIDs", contactEngineerIDs)
+		ctx = context.WithValue(ctx, "recruiter", recruiter)
+		if user != nil {
+			ctx = context.WithValue(ctx, "userEmail", user.Email)
+		}
 		r = r.WithContext(ctx)
 		next.ServeHTTP(w, r)
 	})
@@ -0,0 +1,54 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"angular-talents-backend/dao"
+	"angular-talents-backend/internal"
+
+	"github.com/google/uuid"
+)
+
+// ValidateRecruiter must run after ValidateAuth. It loads the recruiter profile of the
+// authenticated user into the context and rejects users without one.
+func ValidateRecruiter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := r.Context().Value("userID").(uuid.UUID)
+
+		recruiter, err := dao.FindRecruiterByUser(r.Context(), userID)
+		if err != nil {
+			err := internal.NewError(http.StatusInternalServerError, "recruiter.find_recruiter", "failed to retrieve recruiter", err.Error())
+			internal.WriteError(w, err)
+			return
+		}
+
+		if recruiter == nil {
+			err := internal.NewError(http.StatusForbidden, "recruiter.find_recruiter", "failed to retrieve recruiter", "no recruiter profile attached to user")
+			internal.WriteError(w, err)
+			return
+		}
+
+		user, err := dao.FindUserById(r.Context(), userID)
+		if err != nil {
+			err := internal.NewError(http.StatusInternalServerError, "recruiter.find_user", "failed to retrieve user", err.Error())
+			internal.WriteError(w, err)
+			return
+		}
+
+		contactEngineerIDs, err := dao.ReadAcceptedContactEngineerIDs(r.Context(), recruiter.ID)
+		if err != nil {
+			err := internal.NewError(http.StatusInternalServerError, "recruiter.find_contacts", "failed to retrieve recruiter contacts", err.Error())
+			internal.WriteError(w, err)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "recruiter", recruiter)
+		ctx = context.WithValue(ctx, "isMember", recruiter.IsMember)
+		ctx = context.WithValue(ctx, "contactEngineerIDs", contactEngineerIDs)
+		if user != nil {
+			ctx = context.WithValue(ctx, "userEmail", user.Email)
+		}
+		r = r.WithContext(ctx)
+		next.ServeHTTP(w, r)
+	})
+}
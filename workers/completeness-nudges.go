@@ -0,0 +1,105 @@
+package workers
+
+import (
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxNudgeHints is the number of missing items listed in a nudge, so the email stays short.
+const maxNudgeHints = 3
+
+// SendCompletenessNudges reminds engineers and recruiters whose profile has been stuck below the
+// completeness threshold to fill it in, listing what would raise their score the most. Profiles
+// written before scores were stored are scored first.
+func SendCompletenessNudges(ctx context.Context) error {
+	templateId := os.Getenv("COMPLETENESS_NUDGE_TEMPLATE_ID")
+	settings := domain.CompletenessNudgeSettingsFromEnv()
+	now := time.Now()
+	cutoff := now.Add(-settings.After)
+
+	scored, err := dao.BackfillCompleteness(ctx)
+	if err != nil {
+		return err
+	}
+	if scored > 0 {
+		internal.LogInfo("Scored profile completeness", map[string]interface{}{"profiles": scored})
+	}
+
+	engineers, err := dao.ReadEngineersToNudge(ctx, settings, cutoff)
+	if err != nil {
+		return err
+	}
+
+	// a failed nudge leaves the profile unmarked, so it is tried again at the next run
+	var lastErr error
+	failed := 0
+	for _, engineer := range engineers {
+		err := sendCompletenessNudge(ctx, templateId, domain.ProfileTypeEngineer, engineer.ID, engineer.UserID, engineer.Firstname, engineer.Completeness(), now)
+		if err != nil {
+			internal.LogInfo("Failed to send profile completeness nudge", map[string]interface{}{"engineer_id": engineer.ID, "error": err.Error()})
+			lastErr = fmt.Errorf("engineer %s: %w", engineer.ID, err)
+			failed++
+		}
+	}
+
+	recruiters, err := dao.ReadRecruitersToNudge(ctx, settings, cutoff)
+	if err != nil {
+		return err
+	}
+
+	for _, recruiter := range recruiters {
+		err := sendCompletenessNudge(ctx, templateId, domain.ProfileTypeRecruiter, recruiter.ID, recruiter.UserID, recruiter.Firstname, recruiter.Completeness(), now)
+		if err != nil {
+			internal.LogInfo("Failed to send profile completeness nudge", map[string]interface{}{"recruiter_id": recruiter.ID, "error": err.Error()})
+			lastErr = fmt.Errorf("recruiter %s: %w", recruiter.ID, err)
+			failed++
+		}
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("%d of %d nudges failed, last: %w", failed, len(engineers)+len(recruiters), lastErr)
+	}
+
+	return nil
+}
+
+func sendCompletenessNudge(ctx context.Context, templateId, profileType string, profileID, userID uuid.UUID, firstname string, completeness domain.Completeness, now time.Time) error {
+	user, err := dao.FindUserById(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user != nil {
+		hints := completeness.Missing
+		if len(hints) > maxNudgeHints {
+			hints = hints[:maxNudgeHints]
+		}
+
+		var items []string
+		for _, hint := range hints {
+			items = append(items, hint.Hint)
+		}
+
+		err = domain.SendTemplateEmail(templateId, user.Email, map[string]interface{}{
+			"first_name": firstname,
+			"score": completeness.Score,
+			"hints": items,
+			"url": fmt.Sprintf("%s/profile", domain.FrontendURL()),
+		})
+		if err != nil {
+			return err
+		}
+
+		internal.LogInfo("Sent profile completeness nudge", map[string]interface{}{"user_id": userID, "score": completeness.Score})
+	}
+
+	// profiles without a user are marked too, so they aren't picked again at every run
+	return dao.MarkCompletenessNudged(ctx, profileType, profileID, now)
+}
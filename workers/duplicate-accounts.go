@@ -0,0 +1,41 @@
+package workers
+
+import (
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"context"
+	"time"
+)
+
+// FlagDuplicateAccounts puts the engineer profiles that look like the same person up for review
+// by an admin: those sharing a GitHub account, a LinkedIn profile, or a name in the same country.
+// Accounts created before emails were normalized get theirs stored first.
+func FlagDuplicateAccounts(ctx context.Context) error {
+	normalized, err := dao.BackfillNormalizedEmails(ctx)
+	if err != nil {
+		return err
+	}
+	if normalized > 0 {
+		internal.LogInfo("Normalized user emails", map[string]interface{}{"users": normalized})
+	}
+
+	engineers, err := dao.ReadEngineersForDuplicateCheck(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, duplicates := range domain.FindDuplicateEngineers(engineers) {
+		queued, err := dao.InsertDuplicateFlags(ctx, duplicates.Flags(now))
+		if err != nil {
+			return err
+		}
+
+		if queued > 0 {
+			internal.LogInfo("Flagged duplicate engineers", map[string]interface{}{"field": duplicates.Field, "engineers": len(duplicates.Engineers)})
+		}
+	}
+
+	return nil
+}
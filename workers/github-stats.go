@@ -0,0 +1,90 @@
+package workers
+
+import (
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// githubRefreshInterval is how old cached stats get before they are fetched again.
+	githubRefreshInterval = 7 * 24 * time.Hour
+	// githubRetryDelay is how long a profile whose refresh failed waits for another attempt.
+	githubRetryDelay = 24 * time.Hour
+)
+
+// RefreshGithubStats fetches the GitHub stats of the engineers whose cached stats are missing or
+// stale. Each run handles a small batch, sized to stay within GitHub's hourly rate limit, and
+// stops early when the limit is reached anyway.
+func RefreshGithubStats(ctx context.Context) error {
+	client := domain.CurrentGithubClient()
+	now := time.Now()
+
+	engineers, err := dao.ReadEngineersForGithubRefresh(ctx, now.Add(-githubRefreshInterval), githubBatchSize())
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	failed := 0
+	for _, engineer := range engineers {
+		err := refreshGithubStats(ctx, client, engineer, now)
+		if errors.Is(err, domain.ErrGithubRateLimited) {
+			internal.LogInfo("GitHub rate limit reached, resuming at next run", map[string]interface{}{"engineer_id": engineer.ID})
+			break
+		}
+		if err != nil {
+			lastErr = fmt.Errorf("engineer %s: %w", engineer.ID, err)
+			failed++
+		}
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("%d of %d refreshes failed, last: %w", failed, len(engineers), lastErr)
+	}
+
+	return nil
+}
+
+func refreshGithubStats(ctx context.Context, client domain.GithubClient, engineer *domain.Engineer, now time.Time) error {
+	login := domain.GithubLogin(engineer.Github)
+	if login == "" {
+		return dao.UpdateEngineerGithubStats(ctx, engineer.ID, engineer.Github, nil, now)
+	}
+
+	stats, err := domain.FetchGithubStats(ctx, client, login, now)
+	switch {
+	case errors.Is(err, domain.ErrGithubUserNotFound):
+		return dao.UpdateEngineerGithubStats(ctx, engineer.ID, engineer.Github, nil, now)
+	case errors.Is(err, domain.ErrGithubRateLimited):
+		return err
+	case err != nil:
+		markErr := dao.MarkEngineerGithubChecked(ctx, engineer.ID, engineer.Github, now.Add(githubRetryDelay-githubRefreshInterval))
+		if markErr != nil {
+			return markErr
+		}
+		return err
+	}
+
+	err = dao.UpdateEngineerGithubStats(ctx, engineer.ID, engineer.Github, stats, now)
+	if err != nil {
+		return err
+	}
+
+	internal.LogInfo("Refreshed GitHub stats", map[string]interface{}{"engineer_id": engineer.ID, "login": login})
+	return nil
+}
+
+// githubBatchSize keeps unauthenticated runs within 60 requests an hour, as each profile takes
+// three to seven requests.
+func githubBatchSize() int64 {
+	if os.Getenv("GITHUB_TOKEN") == "" && os.Getenv("GITHUB_CLIENT") != "fixture" {
+		return 8
+	}
+	return 200
+}
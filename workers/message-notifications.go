@@ -0,0 +1,90 @@
+package workers
+
+import (
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// messageNotificationDelay leaves recipients who are online a chance to read a message before
+// it is emailed to them.
+const messageNotificationDelay = 15 * time.Minute
+
+// SendMessageNotifications emails each user a single summary of the messages they have not
+// read yet, rather than one email per message.
+func SendMessageNotifications(ctx context.Context) error {
+	templateId := os.Getenv("NEW_MESSAGES_TEMPLATE_ID")
+
+	messages, err := dao.ReadMessagesToNotify(ctx, time.Now().Add(-messageNotificationDelay))
+	if err != nil {
+		return err
+	}
+
+	byRecipient := map[uuid.UUID][]*domain.Message{}
+	var recipients []uuid.UUID
+	for _, message := range messages {
+		if _, ok := byRecipient[message.RecipientID]; !ok {
+			recipients = append(recipients, message.RecipientID)
+		}
+		byRecipient[message.RecipientID] = append(byRecipient[message.RecipientID], message)
+	}
+
+	var lastErr error
+	failed := 0
+	for _, recipientID := range recipients {
+		err := sendMessageNotification(ctx, templateId, recipientID, byRecipient[recipientID])
+		if err != nil {
+			internal.LogInfo("Failed to send new messages notification", map[string]interface{}{"user_id": recipientID, "error": err.Error()})
+			lastErr = fmt.Errorf("recipient %s: %w", recipientID, err)
+			failed++
+		}
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("%d of %d notifications failed, last: %w", failed, len(recipients), lastErr)
+	}
+
+	return nil
+}
+
+func sendMessageNotification(ctx context.Context, templateId string, recipientID uuid.UUID, messages []*domain.Message) error {
+	var messageIDs []uuid.UUID
+	conversations := map[uuid.UUID]bool{}
+	var previews []map[string]interface{}
+	for _, message := range messages {
+		messageIDs = append(messageIDs, message.ID)
+		if !conversations[message.ConversationID] {
+			conversations[message.ConversationID] = true
+			previews = append(previews, map[string]interface{}{
+				"preview": message.Preview(),
+				"url": fmt.Sprintf("%s/conversations/%s", domain.FrontendURL(), message.ConversationID),
+			})
+		}
+	}
+
+	user, err := dao.FindUserById(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+
+	if user != nil {
+		err = domain.SendTemplateEmail(templateId, user.Email, map[string]interface{}{
+			"message_count": len(messages),
+			"conversation_count": len(previews),
+			"conversations": previews,
+		})
+		if err != nil {
+			return err
+		}
+
+		internal.LogInfo("Sent new messages notification", map[string]interface{}{"user_id": recipientID, "message_count": len(messages)})
+	}
+
+	return dao.MarkMessagesNotified(ctx, messageIDs)
+}
@@ -0,0 +1,152 @@
+package workers
+
+import (
+	"angular-talents-backend/dao"
+	"angular-talents-backend/domain"
+	"angular-talents-backend/internal"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxEngineersPerDigest = 50
+
+// SendSavedSearchAlerts emails each recruiter whose saved search is due the engineers that
+// started matching it since its previous digest. A digest holds at most maxEngineersPerDigest
+// engineers, and the search's next run resumes after the last of them.
+func SendSavedSearchAlerts(ctx context.Context) error {
+	now := time.Now()
+	templateId := os.Getenv("SAVED_SEARCH_DIGEST_TEMPLATE_ID")
+
+	searches, err := dao.ReadDueSavedSearches(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	failed := 0
+	for _, search := range searches {
+		err := sendSavedSearchDigest(ctx, templateId, search, now)
+		if err != nil {
+			internal.LogInfo("Failed to send saved search digest", map[string]interface{}{"search_id": search.ID, "error": err.Error()})
+			lastErr = fmt.Errorf("saved search %s: %w", search.ID, err)
+			failed++
+		}
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("%d of %d digests failed, last: %w", failed, len(searches), lastErr)
+	}
+
+	return nil
+}
+
+func sendSavedSearchDigest(ctx context.Context, templateId string, search *domain.SavedSearch, now time.Time) error {
+	recruiter, err := dao.FindRecruiterById(ctx, search.RecruiterID.String())
+	if err != nil {
+		return err
+	}
+
+	if recruiter == nil || !recruiter.IsMember {
+		return dao.UpdateSavedSearchRun(ctx, search.ID, now, nil)
+	}
+
+	user, err := dao.FindUserById(ctx, recruiter.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return dao.UpdateSavedSearchRun(ctx, search.ID, now, nil)
+	}
+
+	lastRunAt := search.LastRunAt
+	viewer := &domain.Viewer{UserID: recruiter.UserID, IsMember: recruiter.IsMember, Company: recruiter.Company, EmailDomain: domain.EmailDomain(user.Email)}
+	engineers, err := dao.ReadEngineers(ctx, &domain.ListEngineersParams{
+		Pagination: &domain.ListEngineersPagination{Page: 1, Limit: maxEngineersPerDigest},
+		Filter: search.Filter,
+		Viewer: viewer,
+		ChangedSince: &lastRunAt,
+		ExcludeIDs: search.NotifiedEngineerIDs,
+		Sort: domain.EngineerSortChanged,
+	})
+	if err != nil {
+		return err
+	}
+
+	var matches []map[string]interface{}
+	var notified []uuid.UUID
+	for _, engineer := range engineers {
+		if search.WasNotified(engineer.ID) {
+			continue
+		}
+
+		match, err := digestEntry(viewer, engineer)
+		if err != nil {
+			return err
+		}
+
+		matches = append(matches, match)
+		notified = append(notified, engineer.ID)
+	}
+
+	if len(matches) > 0 {
+		err = domain.SendTemplateEmail(templateId, user.Email, map[string]interface{}{
+			"search_name": search.Name,
+			"frequency": search.Frequency,
+			"engineer_count": len(matches),
+			"engineers": matches,
+		})
+		if err != nil {
+			return err
+		}
+
+		internal.LogInfo("Sent saved search digest", map[string]interface{}{"search_id": search.ID, "recruiter_id": recruiter.ID, "engineer_count": len(matches)})
+	}
+
+	runAt := now
+	if len(engineers) == maxEngineersPerDigest {
+		runAt = engineers[len(engineers)-1].UpdatedAt
+	}
+
+	return dao.UpdateSavedSearchRun(ctx, search.ID, runAt, notified)
+}
+
+// digestEntry describes the engineer in the digest with the fields the recruiter may see, leaving
+// out the concealed ones.
+func digestEntry(viewer *domain.Viewer, engineer *domain.Engineer) (map[string]interface{}, error) {
+	view, err := viewer.EngineerView(engineer)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := map[string]interface{}{
+		"url": fmt.Sprintf("%s/engineers/%s", domain.FrontendURL(), engineer.ID),
+	}
+
+	if name := joinViewFields(view, " ", "Firstname", "Lastname"); name != "" {
+		entry["name"] = name
+	}
+	if tagline, ok := view["Tagline"].(string); ok && tagline != "" {
+		entry["tagline"] = tagline
+	}
+	if location := joinViewFields(view, ", ", "City", "Country"); location != "" {
+		entry["location"] = location
+	}
+
+	return entry, nil
+}
+
+func joinViewFields(view map[string]interface{}, separator string, fields ...string) string {
+	values := []string{}
+	for _, field := range fields {
+		if value, ok := view[field].(string); ok && value != "" {
+			values = append(values, value)
+		}
+	}
+	return strings.Join(values, separator)
+}
@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"angular-talents-backend/internal"
+	"context"
+	"net/http"
+	"time"
+)
+
+// Job is a unit of background work run periodically by Every.
+type Job func(ctx context.Context) error
+
+// Every runs the job at each interval until the context is cancelled. Failures are logged and
+// the job is retried at the next tick.
+func Every(ctx context.Context, name string, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run(ctx, name, job)
+		}
+	}
+}
+
+func run(ctx context.Context, name string, job Job) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err := internal.NewError(http.StatusInternalServerError, "worker."+name, "background job panicked", "panic in background job")
+			internal.LogError(err, map[string]interface{}{"panic": recovered})
+		}
+	}()
+
+	internal.LogInfo("Starting background job", map[string]interface{}{"job": name})
+	err := job(ctx)
+	if err != nil {
+		customErr := internal.NewError(http.StatusInternalServerError, "worker."+name, "background job failed", err.Error())
+		internal.LogError(customErr, map[string]interface{}{"job": name})
+		return
+	}
+	internal.LogInfo("Successfully ran background job", map[string]interface{}{"job": name})
+}